@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"user.app/pkg/audit"
+	"user.app/pkg/conn"
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Maintenance tasks for the audit log",
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check the hash chain of the audit log",
+	Long: "Check the hash chain of the audit log. It exits with a non-zero code when an event has been modified, " +
+		"removed or inserted outside of user.app.",
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		checked, err := audit.Verify(context.Background(), instance)
+		if err != nil {
+			if errors.Cause(err) == audit.ErrChainBroken {
+				fmt.Printf("%d events verified, %v\n", checked, err)
+				os.Exit(1)
+			}
+			log.Fatal().Err(err).Msg("cannot verify the audit log")
+		}
+		fmt.Printf("%d events verified, the chain is intact\n", checked)
+	},
+}
+
+func init() {
+	auditCmd.AddCommand(verifyCmd)
+	RootCmd.AddCommand(auditCmd)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"net/url"
+	"strings"
+	"time"
+	"user.app/pkg/auth"
+	"user.app/pkg/conn"
+	"user.app/pkg/oauth"
+	"user.app/pkg/query"
+	"user.app/pkg/validation"
+)
+
+var clientsCmd = &cobra.Command{
+	Use:   "clients",
+	Short: "Manage the OAuth2 clients",
+}
+
+var createClientCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Register an OAuth2 client and print its credentials",
+	Long: "Register an OAuth2 client and print its credentials. The secret is only printed once, only its hash is " +
+		"stored. The client_credentials tokens are issued to the service account of the client, which must be a " +
+		"superuser for the admin scope.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		serviceAccountID, _ := cmd.Flags().GetString("service-account")
+		grantTypes, _ := cmd.Flags().GetStringSlice("grant-types")
+		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		redirectURIs, _ := cmd.Flags().GetStringSlice("redirect-uris")
+
+		if len(name) == 0 || len(name) > validation.MaxAPIKeyNameLength {
+			log.Fatal().Msgf("the name is required and cannot exceed %d characters", validation.MaxAPIKeyNameLength)
+		}
+		for _, grantType := range grantTypes {
+			if !contains(oauth.GrantTypes, grantType) {
+				log.Fatal().Msgf("unsupported grant type %q, it must be one of %s", grantType, strings.Join(oauth.GrantTypes, ", "))
+			}
+		}
+		supportedScopes := append(append([]string{}, auth.Scopes...), oauth.OIDCScopes...)
+		for _, scope := range scopes {
+			if !contains(supportedScopes, scope) {
+				log.Fatal().Msgf("unsupported scope %q, it must be one of %s", scope, strings.Join(supportedScopes, ", "))
+			}
+		}
+		clientCredentials := contains(grantTypes, oauth.GrantClientCredentials)
+		authorizationCode := contains(grantTypes, oauth.GrantAuthorizationCode)
+		if !clientCredentials && !authorizationCode {
+			log.Fatal().Msg("the client_credentials or the authorization_code grant is required, they are the only ones issuing new tokens")
+		}
+		if clientCredentials {
+			if _, err := uuid.FromString(serviceAccountID); err != nil {
+				log.Fatal().Msg("the ID of the service account of the client is required by the client_credentials grant")
+			}
+		} else if len(serviceAccountID) > 0 {
+			log.Fatal().Msg("the service account is only used by the client_credentials grant")
+		}
+		if contains(grantTypes, oauth.GrantRefreshToken) && !authorizationCode {
+			log.Fatal().Msg("the refresh_token grant requires the authorization_code grant, the client_credentials " +
+				"grant never issues refresh tokens")
+		}
+		if authorizationCode {
+			if len(viper.GetString("oauth.signing_key_file")) == 0 {
+				log.Fatal().Msg("oauth.signing_key_file is required by the authorization_code grant, the ID tokens " +
+					"would be signed with an ephemeral key")
+			}
+			if len(redirectURIs) == 0 {
+				log.Fatal().Msg("the redirect URIs are required by the authorization_code grant")
+			}
+			if !contains(scopes, oauth.ScopeOpenID) {
+				log.Fatal().Msg("the openid scope is required by the authorization_code grant")
+			}
+		} else if len(redirectURIs) > 0 {
+			log.Fatal().Msg("the redirect URIs are only used by the authorization_code grant")
+		}
+		for _, redirectURI := range redirectURIs {
+			u, err := url.Parse(redirectURI)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 || len(u.Fragment) > 0 {
+				log.Fatal().Msgf("invalid redirect URI %q, it must be an absolute http or https URL without fragment", redirectURI)
+			}
+		}
+
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		ctx := context.Background()
+		superuser := false
+		if clientCredentials {
+			account, err := query.FindServiceAccount(ctx, instance, serviceAccountID)
+			if err != nil {
+				log.Fatal().Err(err).Msg("cannot find the service account")
+			}
+			serviceAccountID, superuser = account.ID, account.IsSuperuser.Bool
+		}
+		if contains(scopes, auth.ScopeAdmin) && !superuser {
+			log.Fatal().Msg("the admin scope can only be granted to the clients of a superuser service account")
+		}
+
+		client, secret, err := oauth.NewClient(name, serviceAccountID, grantTypes, scopes, redirectURIs)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot generate the client credentials")
+		}
+		if err = query.CreateOAuthClient(ctx, instance, client); err != nil {
+			log.Fatal().Err(err).Msg("cannot register the client")
+		}
+		fmt.Printf("client_id:     %s\nclient_secret: %s\n", client.ID, secret)
+	},
+}
+
+var listClientsCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the OAuth2 clients that have not been revoked",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		clients, err := query.ListOAuthClients(context.Background(), instance)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot list the clients")
+		}
+		for _, client := range clients {
+			fmt.Printf("%s %q service account %s, grants %s, scopes %s, redirect URIs %s, created at %s\n", client.ID,
+				client.Name, client.ServiceAccountID.String, strings.Join(client.GrantTypes, ","),
+				strings.Join(client.Scopes, ","), strings.Join(client.RedirectUris, ","), client.CreatedAt)
+		}
+		fmt.Printf("%d clients\n", len(clients))
+	},
+}
+
+var revokeClientCmd = &cobra.Command{
+	Use:   "revoke <client_id>",
+	Short: "Revoke an OAuth2 client and its refresh tokens",
+	Long: "Revoke an OAuth2 client and its refresh tokens. The access tokens already issued stay valid until they " +
+		"expire, revoke them with the revocation endpoint to sign the client out at once.",
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		if err = query.RevokeOAuthClient(context.Background(), instance, args[0], time.Now()); err != nil {
+			if err == query.ErrNoRowsFound {
+				log.Fatal().Msgf("there is no client %s or it is already revoked", args[0])
+			}
+			log.Fatal().Err(err).Msg("cannot revoke the client")
+		}
+		fmt.Printf("client %s revoked\n", args[0])
+	},
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	createClientCmd.Flags().String("name", "", "Name of the client")
+	createClientCmd.Flags().String("service-account", "", "ID of the service account the tokens are issued to")
+	createClientCmd.Flags().StringSlice("grant-types", []string{oauth.GrantClientCredentials},
+		"Grant types of the client: authorization_code, client_credentials, refresh_token")
+	createClientCmd.Flags().StringSlice("scopes", nil,
+		"Scopes the client can request: read, write, admin, openid, profile, email")
+	createClientCmd.Flags().StringSlice("redirect-uris", nil, "Redirect URIs of the authorization_code grant")
+
+	clientsCmd.AddCommand(createClientCmd)
+	clientsCmd.AddCommand(listClientsCmd)
+	clientsCmd.AddCommand(revokeClientCmd)
+	RootCmd.AddCommand(clientsCmd)
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"user.app/pkg/api"
+	"user.app/pkg/openapi"
+)
+
+// protoFile is the proto file of the UserApp service, its descriptor is registered by the message package
+const protoFile = "message/message.proto"
+
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Print the OpenAPI document of the REST gateway",
+	Long: "Print the OpenAPI document generated from the google.api.http annotations of message.proto. The " +
+		"document is checked in as message/openapi.json.",
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, err := openAPISpec()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot generate the OpenAPI document")
+		}
+		if _, err = os.Stdout.Write(spec); err != nil {
+			log.Fatal().Err(err).Msg("cannot write the OpenAPI document")
+		}
+	},
+}
+
+func openAPISpec() ([]byte, error) {
+	doc, err := openapi.Generate(protoFile, api.PublicMethods)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.Marshal(doc)
+}
+
+func init() {
+	RootCmd.AddCommand(openapiCmd)
+}
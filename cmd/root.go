@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"user.app/pkg/logging"
 )
 
 var (
@@ -33,6 +34,9 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatal().Err(err).Msg("unable to read config.")
 	}
+	if err := logging.Configure(); err != nil {
+		log.Fatal().Err(err).Msg("invalid log configuration")
+	}
 }
 
 func init() {
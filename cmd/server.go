@@ -1,27 +1,66 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"github.com/soheilhy/cmux"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"github.com/volatiletech/sqlboiler/v4/boil"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"user.app/message"
 	"user.app/pkg/api"
+	"user.app/pkg/audit"
 	"user.app/pkg/auth"
 	"user.app/pkg/conn"
+	"user.app/pkg/events"
+	"user.app/pkg/gateway"
+	"user.app/pkg/health"
+	"user.app/pkg/logging"
+	"user.app/pkg/metrics"
+	"user.app/pkg/oauth"
+	"user.app/pkg/openapi"
+	"user.app/pkg/outbox"
+	"user.app/pkg/ratelimit"
+	"user.app/pkg/retention"
+	"user.app/pkg/tracing"
+	"user.app/pkg/transport"
+	"user.app/pkg/validation"
+	"user.app/pkg/webhook"
+)
+
+const (
+	// DefaultShutdownTimeoutInSeconds is used when shutdown_timeout is not configured
+	DefaultShutdownTimeoutInSeconds = 30
+
+	// exitOK is the exit code of a server drained after a signal
+	exitOK = 0
+	// exitServeError is the exit code of a server stopped by the failure of one of its listeners or jobs
+	exitServeError = 1
+	// exitDrainTimeout is the exit code of a server whose in-flight requests were cut by the shutdown deadline
+	exitDrainTimeout = 2
+
+	// userAppService is the name of the service in the gRPC health checks
+	userAppService = "message.UserApp"
 )
 
 var applicationCmd = &cobra.Command{
 	Use:   "server",
 	Short: "Run the userapp RPC service",
+	Long: "Run the userapp RPC service. On SIGINT or SIGTERM it stops accepting connections and waits for the " +
+		"in-flight requests and background jobs until the shutdown timeout. It exits with 0 once drained, 1 when a " +
+		"listener or a job failed and 2 when the shutdown timeout cut the drain short.",
 	Run: func(cmd *cobra.Command, args []string) {
-		boil.DebugMode = true
 		var err error
 		conn.Instance, err = conn.InitDBConnection()
 		if err != nil {
@@ -33,38 +72,276 @@ var applicationCmd = &cobra.Command{
 		}
 		auth.Authenticator = authenticator
 
+		endpoints, err := webhook.Endpoints()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid webhooks configuration")
+		}
+
+		events.RegisterUserHooks(events.DefaultBus)
+		audit.Subscribe(events.DefaultBus)
+		outbox.Subscribe(events.DefaultBus, webhook.Names(endpoints))
+		authenticator.Subscribe(events.DefaultBus)
+		oauth.Subscribe(events.DefaultBus)
+
+		tracingConfig, err := tracing.LoadConfig()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid tracing configuration")
+		}
+		flushSpans, err := tracing.Init(tracingConfig)
+		if err != nil {
+			log.Fatal().Err(err).Msg("tracing initialization failed")
+		}
+
+		rateLimitConfig, err := ratelimit.LoadConfig()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid rate_limit configuration")
+		}
+		limiter := ratelimit.New(rateLimitConfig, conn.Instance)
+
+		oauthConfig, err := oauth.LoadConfig()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid oauth configuration")
+		}
+		oauthServer, err := oauth.NewServer(oauthConfig, conn.Instance, authenticator, limiter)
+		if err != nil {
+			log.Fatal().Err(err).Msg("oauth initialization failed")
+		}
+
+		tlsConfig, err := transport.LoadConfig()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid tls configuration")
+		}
+
 		serviceAddr := fmt.Sprintf("%s:%d", viper.GetString("host"), viper.GetInt("port"))
 		listener, err := net.Listen("tcp", serviceAddr)
 		if err != nil {
 			log.Fatal().Err(err).Msg("unable to start cmux listener")
 		}
 
-		m := cmux.New(listener)
-		http2Listener := m.Match(cmux.HTTP2())
-		serverObj := &api.Server{}
-
-		g := new(errgroup.Group)
-		g.Go(func() error {
-			gRPCServer := grpc.NewServer(
-				grpc.UnaryInterceptor(
-					grpc_middleware.ChainUnaryServer(
-						auth.UnaryServerInterceptor(),
-					),
+		shutdown := make(chan struct{})
+		serverObj := &api.Server{
+			Shutdown: shutdown,
+		}
+		gRPCServer := grpc.NewServer(
+			grpc.UnaryInterceptor(
+				grpc_middleware.ChainUnaryServer(
+					tracing.UnaryServerInterceptor(),
+					metrics.UnaryServerInterceptor(),
+					logging.UnaryServerInterceptor(),
+					auth.UnaryServerInterceptor(),
+					limiter.UnaryServerInterceptor(),
+					validation.UnaryServerInterceptor(),
+				),
+			),
+			grpc.StreamInterceptor(
+				grpc_middleware.ChainStreamServer(
+					tracing.StreamServerInterceptor(),
+					metrics.StreamServerInterceptor(),
+					logging.StreamServerInterceptor(),
+					auth.StreamServerInterceptor(),
+					limiter.StreamServerInterceptor(),
+					validation.StreamServerInterceptor(),
 				),
-			)
+			),
+		)
+		message.RegisterUserAppServer(gRPCServer, serverObj)
 
-			message.RegisterUserAppServer(gRPCServer, serverObj)
-			return gRPCServer.Serve(http2Listener)
+		checker := health.NewChecker(userAppService)
+		checker.Register("database", func(ctx context.Context) error {
+			if conn.Instance == nil {
+				return errors.New("no database connection")
+			}
+			return conn.Instance.PingContext(ctx)
 		})
-		g.Go(func() error { return m.Serve() })
+		checker.Register("sessions", authenticator.Ping)
+		checker.RegisterServer(gRPCServer)
+
+		metrics.InitializeMethods(gRPCServer)
+		metrics.RegisterSessionGauge(authenticator.CountSessions)
+		if conn.Instance != nil {
+			metrics.RegisterDB(conn.Instance)
+		}
+
+		var reloader *transport.Reloader
+		gatewayDialOptions := []grpc.DialOption{grpc.WithInsecure(), transport.GatewayDialOption()}
+		if tlsConfig.Enabled {
+			reloader, err = transport.NewReloader(tlsConfig)
+			if err != nil {
+				log.Fatal().Err(err).Msg("cannot load the certificates")
+			}
+			gatewayDialOptions = []grpc.DialOption{
+				grpc.WithTransportCredentials(transport.LoopbackCredentials(reloader)),
+				transport.GatewayDialOption(),
+			}
+		}
+
+		gatewayHandler, err := gateway.NewHandler(context.Background(), serviceAddr, gatewayDialOptions...)
+		if err != nil {
+			log.Fatal().Err(err).Msg("rest gateway initialization failed")
+		}
+		spec, err := openAPISpec()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot generate the OpenAPI document")
+		}
+		httpMux := http.NewServeMux()
+		httpMux.Handle("/openapi.json", openapi.Handler(spec))
+		httpMux.Handle("/healthz", checker.LiveHandler())
+		httpMux.Handle("/readyz", checker.ReadyHandler())
+		oauthServer.Register(httpMux)
+		if metrics.Enabled() {
+			httpMux.Handle(metrics.Path(), metrics.Handler())
+		}
+		httpMux.Handle("/", gatewayHandler)
+		grpcWebHandler := gateway.NewGRPCWebHandler(gRPCServer, gateway.AllowedOrigins(), gateway.AllowedHeaders())
+
+		// the errors of the servers are expected once the drain has started
+		serve := func(fn func() error) func() error {
+			return func() error {
+				err := fn()
+				select {
+				case <-shutdown:
+					return nil
+				default:
+					return err
+				}
+			}
+		}
+
+		g, failed := errgroup.WithContext(context.Background())
+		var httpServer *http.Server
+		if tlsConfig.Enabled {
+			// the browsers negotiate HTTP/2 over TLS for the REST and gRPC-Web calls too, so the connections cannot be
+			// told apart by cmux. The requests are dispatched one by one instead, the gRPC ones to the gRPC server
+			// through its HTTP handler which also exposes the client certificates.
+			httpServer = &http.Server{Handler: gateway.Dispatch(gRPCServer, grpcWebHandler, httpMux)}
+			g.Go(serve(func() error { return httpServer.Serve(tls.NewListener(listener, reloader.TLSConfig())) }))
+		} else {
+			m := cmux.New(listener)
+			http2Listener := m.Match(cmux.HTTP2())
+			http1Listener := m.Match(cmux.HTTP1Fast())
+			httpServer = &http.Server{Handler: gateway.Dispatch(nil, grpcWebHandler, httpMux)}
+			g.Go(serve(func() error { return gRPCServer.Serve(http2Listener) }))
+			g.Go(serve(func() error { return httpServer.Serve(http1Listener) }))
+			g.Go(serve(m.Serve))
+		}
+
+		jobs, stopJobs := context.WithCancel(context.Background())
+		g.Go(func() error { return ignoreCanceled(checker.Run(jobs)) })
+		g.Go(func() error { return ignoreCanceled(retention.NewPurger(conn.Instance).Run(jobs)) })
+		g.Go(func() error { return ignoreCanceled(webhook.NewDispatcher(conn.Instance, endpoints).Run(jobs)) })
+		g.Go(func() error { return ignoreCanceled(limiter.Run(jobs)) })
+		g.Go(func() error { return ignoreCanceled(oauthServer.Run(jobs)) })
+		if reloader != nil {
+			g.Go(func() error { return reloader.Watch(jobs.Done()) })
+		}
 		log.Info().Msgf("application server listening on %s", serviceAddr)
-		if err := g.Wait(); err != nil {
-			log.Fatal().Err(err).Msg("err-group first non-nil error")
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+		exitCode := exitOK
+		select {
+		case sig := <-signals:
+			log.Info().Msgf("%s received, draining", sig)
+		case <-failed.Done():
+			// the first error is returned by g.Wait once the drain is over
+			exitCode = exitServeError
 		}
+		close(shutdown)
+
+		// the probes fail first, the load balancers stop sending new requests during the drain delay
+		checker.Drain()
+		time.Sleep(drainDelay())
+
+		drained := drain(shutdownTimeout(), listener, gRPCServer, httpServer, stopJobs, g)
+		if conn.Instance != nil {
+			if err := conn.Instance.Close(); err != nil {
+				log.Error().Err(err).Msg("cannot close the database connections")
+			}
+		}
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := flushSpans(flushCtx); err != nil {
+			log.Error().Err(err).Msg("cannot flush the spans")
+		}
+		cancelFlush()
+		if !drained && exitCode == exitOK {
+			exitCode = exitDrainTimeout
+		}
+		os.Exit(exitCode)
 	},
 }
 
+// drainDelay is how long the server keeps accepting connections once not ready, 0 unless configured
+func drainDelay() time.Duration {
+	return time.Duration(viper.GetInt("health.drain_delay")) * time.Second
+}
+
+// shutdownTimeout is how long the drain waits for the in-flight requests and the background jobs
+func shutdownTimeout() time.Duration {
+	seconds := viper.GetInt("shutdown_timeout")
+	if seconds <= 0 {
+		seconds = DefaultShutdownTimeoutInSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// drain stops accepting connections, then waits until the deadline for the in-flight requests, the jobs, which
+// complete their current pass, and every goroutine of the group. It returns false when the deadline cut it short.
+// The audit events and the outbox are written in the transactions of the requests, they are flushed with them.
+func drain(timeout time.Duration, listener net.Listener, gRPCServer *grpc.Server, httpServer *http.Server,
+	stopJobs context.CancelFunc, g *errgroup.Group) bool {
+	deadline, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := listener.Close(); err != nil {
+		log.Error().Err(err).Msg("cannot close the listener")
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		// the HTTP requests go first, GracefulStop cannot drain the gRPC calls served through the HTTP handler
+		if err := httpServer.Shutdown(deadline); err != nil {
+			done <- err
+			return
+		}
+		gRPCServer.GracefulStop()
+		stopJobs()
+		done <- g.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Cause(err) != context.DeadlineExceeded {
+			log.Error().Err(err).Msg("the server stopped with an error")
+		}
+		if deadline.Err() == nil {
+			log.Info().Msg("drained")
+			return true
+		}
+	case <-deadline.Done():
+	}
+
+	log.Warn().Msg("shutdown timeout exceeded, closing the remaining connections")
+	stopJobs()
+	_ = httpServer.Close()
+	gRPCServer.Stop()
+	return false
+}
+
+func ignoreCanceled(err error) error {
+	if errors.Cause(err) == context.Canceled {
+		return nil
+	}
+	return err
+}
+
 func init() {
+	applicationCmd.PersistentFlags().Int("shutdown-timeout", DefaultShutdownTimeoutInSeconds,
+		"Seconds to wait for the in-flight requests on shutdown")
+	if err := viper.BindPFlag("shutdown_timeout", applicationCmd.PersistentFlags().Lookup("shutdown-timeout")); err != nil {
+		log.Fatal().Err(err).Msg("viper binding failed for shutdown_timeout")
+	}
+
 	applicationCmd.PersistentFlags().StringP("host", "n", "localhost", "Service Host")
 	if err := viper.BindPFlag("host", applicationCmd.PersistentFlags().Lookup("host")); err != nil {
 		log.Fatal().Err(err).Msg("viper binding failed for host")
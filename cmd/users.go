@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"user.app/models"
+	"user.app/pkg/audit"
+	"user.app/pkg/conn"
+	"user.app/pkg/events"
+	"user.app/pkg/normalize"
+	"user.app/pkg/outbox"
+	"user.app/pkg/query"
+	"user.app/pkg/retention"
+	"user.app/pkg/webhook"
+)
+
+var usersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Maintenance tasks for the users table",
+}
+
+var collisionsCmd = &cobra.Command{
+	Use:   "collisions",
+	Short: "List the users whose username or email collide once normalized",
+	Long: "List the users whose username or email collide once normalized. It exits with a non-zero code when " +
+		"collisions are found, they have to be resolved before the normalized unique indexes can be created.",
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		users, err := query.ListUsers(context.Background(), instance)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot list the users")
+		}
+
+		byUsername := make(map[string]models.UserSlice)
+		byEmail := make(map[string]models.UserSlice)
+		for _, user := range users {
+			key := normalize.UsernameKey(user.Username)
+			byUsername[key] = append(byUsername[key], user)
+			key = normalize.EmailKey(user.Email)
+			byEmail[key] = append(byEmail[key], user)
+		}
+
+		collisions := printCollisions("username", byUsername, func(u *models.User) string { return u.Username })
+		collisions += printCollisions("email", byEmail, func(u *models.User) string { return u.Email })
+		if collisions > 0 {
+			fmt.Printf("%d collisions found\n", collisions)
+			os.Exit(1)
+		}
+		fmt.Println("no collisions found")
+	},
+}
+
+var normalizeCmd = &cobra.Command{
+	Use:   "normalize",
+	Short: "Rewrite the usernames and emails of the users in their canonical form",
+	Long: "Rewrite the usernames and emails of the users in their canonical form, the one the sign ups and the " +
+		"updates store. It exits with a non-zero code when a user cannot be normalized, its username or email has to " +
+		"be changed by hand before the normalized unique indexes can be relied on.",
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		endpoints, err := webhook.Endpoints()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid webhooks configuration")
+		}
+
+		events.RegisterUserHooks(events.DefaultBus)
+		audit.Subscribe(events.DefaultBus)
+		outbox.Subscribe(events.DefaultBus, webhook.Names(endpoints))
+
+		ctx := context.Background()
+		users, err := query.ListUsers(ctx, instance)
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot list the users")
+		}
+
+		var normalized, failed int
+		for _, user := range users {
+			username, err := normalize.Username(user.Username)
+			if err != nil {
+				fmt.Printf("%s username %q: %v\n", user.ID, user.Username, err)
+				failed++
+				continue
+			}
+			email, err := normalize.Email(user.Email)
+			if err != nil {
+				fmt.Printf("%s email %q: %v\n", user.ID, user.Email, err)
+				failed++
+				continue
+			}
+			var columns []string
+			if username != user.Username {
+				user.Username = username
+				columns = append(columns, models.UserColumns.Username)
+			}
+			if email != user.Email {
+				user.Email = email
+				columns = append(columns, models.UserColumns.Email)
+			}
+			if len(columns) == 0 {
+				continue
+			}
+
+			err = conn.ExecuteTx(ctx, instance, func(tx *sql.Tx) error {
+				return query.UpdateUser(ctx, tx, user, columns)
+			})
+			if err != nil {
+				fmt.Printf("%s %q %q: %v\n", user.ID, username, email, err)
+				failed++
+				continue
+			}
+			normalized++
+		}
+		fmt.Printf("%d users normalized, %d users cannot be normalized\n", normalized, failed)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Expire the users whose grace period is over and hard delete the ones whose retention period is over",
+	Run: func(cmd *cobra.Command, args []string) {
+		instance, err := conn.InitDBConnection()
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot initiate the connection with the database")
+		}
+		defer instance.Close()
+
+		endpoints, err := webhook.Endpoints()
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid webhooks configuration")
+		}
+
+		events.RegisterUserHooks(events.DefaultBus)
+		audit.Subscribe(events.DefaultBus)
+		outbox.Subscribe(events.DefaultBus, webhook.Names(endpoints))
+
+		purger := retention.NewPurger(instance)
+		expired, err := purger.ExpireOnce(context.Background())
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot expire the deleted users")
+		}
+		purged, err := purger.PurgeOnce(context.Background())
+		if err != nil {
+			log.Fatal().Err(err).Msg("cannot purge the deleted users")
+		}
+		fmt.Printf("%d users expired, %d users purged\n", expired, purged)
+	},
+}
+
+// printCollisions prints every group having more than one user and returns the number of such groups
+func printCollisions(field string, groups map[string]models.UserSlice, value func(*models.User) string) int {
+	var collisions int
+	for key, users := range groups {
+		if len(users) < 2 {
+			continue
+		}
+		collisions++
+		fmt.Printf("%s %q:\n", field, key)
+		for _, user := range users {
+			fmt.Printf("\t%s %q created at %s\n", user.ID, value(user), user.CreatedAt)
+		}
+	}
+	return collisions
+}
+
+func init() {
+	usersCmd.AddCommand(collisionsCmd)
+	usersCmd.AddCommand(normalizeCmd)
+	usersCmd.AddCommand(purgeCmd)
+	RootCmd.AddCommand(usersCmd)
+}
@@ -7,6 +7,8 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -24,6 +26,31 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_JSON ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_ZIP  ExportFormat = 1
+)
+
+var ExportFormat_name = map[int32]string{
+	0: "EXPORT_FORMAT_JSON",
+	1: "EXPORT_FORMAT_ZIP",
+}
+
+var ExportFormat_value = map[string]int32{
+	"EXPORT_FORMAT_JSON": 0,
+	"EXPORT_FORMAT_ZIP":  1,
+}
+
+func (x ExportFormat) String() string {
+	return proto.EnumName(ExportFormat_name, int32(x))
+}
+
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{0}
+}
+
 type AuthRequest struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -80,8 +107,11 @@ func (m *AuthRequest) GetPassword() string {
 }
 
 type AuthResponse struct {
-	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Token                string   `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Username string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Token    string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// pending_restore is set when the account has been deleted and can still be restored, the token is then only
+	// accepted by RestoreAccount and SignOut
+	PendingRestore       bool     `protobuf:"varint,3,opt,name=pending_restore,json=pendingRestore,proto3" json:"pending_restore,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -126,6 +156,13 @@ func (m *AuthResponse) GetToken() string {
 	return ""
 }
 
+func (m *AuthResponse) GetPendingRestore() bool {
+	if m != nil {
+		return m.PendingRestore
+	}
+	return false
+}
+
 type CreateUserRequest struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -267,268 +304,2694 @@ func (m *UpdateUserRequest) GetEmail() string {
 	return ""
 }
 
-type Empty struct {
+type RestoreUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Empty) Reset()         { *m = Empty{} }
-func (m *Empty) String() string { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()    {}
-func (*Empty) Descriptor() ([]byte, []int) {
+func (m *RestoreUserRequest) Reset()         { *m = RestoreUserRequest{} }
+func (m *RestoreUserRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreUserRequest) ProtoMessage()    {}
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_ebceca9e8703e37f, []int{5}
 }
 
-func (m *Empty) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Empty.Unmarshal(m, b)
+func (m *RestoreUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreUserRequest.Unmarshal(m, b)
 }
-func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+func (m *RestoreUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreUserRequest.Marshal(b, m, deterministic)
 }
-func (m *Empty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Empty.Merge(m, src)
+func (m *RestoreUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreUserRequest.Merge(m, src)
 }
-func (m *Empty) XXX_Size() int {
-	return xxx_messageInfo_Empty.Size(m)
+func (m *RestoreUserRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreUserRequest.Size(m)
 }
-func (m *Empty) XXX_DiscardUnknown() {
-	xxx_messageInfo_Empty.DiscardUnknown(m)
+func (m *RestoreUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreUserRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Empty proto.InternalMessageInfo
+var xxx_messageInfo_RestoreUserRequest proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*AuthRequest)(nil), "message.AuthRequest")
-	proto.RegisterType((*AuthResponse)(nil), "message.AuthResponse")
-	proto.RegisterType((*CreateUserRequest)(nil), "message.CreateUserRequest")
-	proto.RegisterType((*CreateUserResponse)(nil), "message.CreateUserResponse")
-	proto.RegisterType((*UpdateUserRequest)(nil), "message.UpdateUserRequest")
-	proto.RegisterType((*Empty)(nil), "message.Empty")
+func (m *RestoreUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("message/message.proto", fileDescriptor_ebceca9e8703e37f) }
+type ExportRequest struct {
+	Format               ExportFormat `protobuf:"varint,1,opt,name=format,proto3,enum=message.ExportFormat" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
 
-var fileDescriptor_ebceca9e8703e37f = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x92, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0x59, 0xc7, 0x5a, 0x7d, 0x8a, 0xb0, 0xb0, 0x61, 0x89, 0x17, 0xc9, 0x49, 0x41, 0x27,
-	0x28, 0x82, 0x47, 0x87, 0xf6, 0xb0, 0x93, 0x30, 0xd9, 0xc9, 0x83, 0x44, 0xfa, 0x98, 0xc5, 0x35,
-	0x89, 0x49, 0x8a, 0xf8, 0xaf, 0xf8, 0xd7, 0x4a, 0xda, 0xb4, 0x2b, 0xab, 0xba, 0x93, 0xa7, 0xf2,
-	0xf5, 0xe5, 0xfb, 0xde, 0x2f, 0xef, 0x05, 0xc6, 0x39, 0x1a, 0xc3, 0x97, 0x78, 0xe1, 0xbf, 0x13,
-	0xa5, 0xa5, 0x95, 0x24, 0xf2, 0x92, 0x3d, 0xc1, 0xde, 0xb4, 0xb0, 0xaf, 0x73, 0x7c, 0x2f, 0xd0,
-	0x58, 0x42, 0x61, 0xa7, 0x30, 0xa8, 0x05, 0xcf, 0x31, 0xee, 0x1d, 0xf7, 0x4e, 0x76, 0xe7, 0x8d,
-	0x26, 0x23, 0x18, 0x60, 0xce, 0xb3, 0x55, 0x1c, 0x94, 0x85, 0x4a, 0x38, 0x87, 0xe2, 0xc6, 0x7c,
-	0x48, 0x9d, 0xc6, 0xfd, 0xca, 0x51, 0x6b, 0x76, 0x0b, 0xfb, 0x55, 0xb8, 0x51, 0x52, 0x18, 0xdc,
-	0x96, 0x6e, 0xe5, 0x1b, 0x8a, 0x3a, 0xbd, 0x14, 0x8c, 0xc3, 0xf0, 0x4e, 0x23, 0xb7, 0xb8, 0x30,
-	0xa8, 0xff, 0x07, 0xf2, 0x1c, 0x48, 0xbb, 0x85, 0x47, 0x3d, 0x84, 0xc8, 0x65, 0x3e, 0x67, 0xa9,
-	0x6f, 0x11, 0x3a, 0x39, 0x4b, 0x59, 0x02, 0xc3, 0x85, 0x4a, 0xff, 0x20, 0x0a, 0x7e, 0x23, 0xea,
-	0xb7, 0x88, 0x58, 0x04, 0x83, 0x24, 0x57, 0xf6, 0xf3, 0xf2, 0x2b, 0x80, 0xc8, 0x45, 0x4d, 0x95,
-	0x22, 0xd7, 0x10, 0x3e, 0x66, 0x4b, 0x31, 0x13, 0x64, 0x34, 0xa9, 0xf7, 0xd5, 0xda, 0x0e, 0x1d,
-	0x6f, 0xfc, 0xf5, 0xac, 0xa7, 0x10, 0x39, 0xdb, 0x43, 0x61, 0xc9, 0x41, 0x73, 0xa2, 0x4c, 0xa7,
-	0x1b, 0x9a, 0x24, 0x00, 0xeb, 0xcb, 0x12, 0xda, 0x54, 0x3b, 0x43, 0xa6, 0x47, 0x3f, 0xd6, 0x7c,
-	0xc7, 0x1b, 0x80, 0xf5, 0x10, 0x5a, 0x31, 0x9d, 0xc9, 0x74, 0x00, 0xce, 0x00, 0xee, 0x71, 0x85,
-	0xde, 0xb9, 0x05, 0xf7, 0x25, 0x2c, 0x5f, 0xeb, 0xd5, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7b,
-	0x25, 0x52, 0x92, 0xc6, 0x02, 0x00, 0x00,
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{6}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRequest.Unmarshal(m, b)
+}
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
+}
+func (m *ExportRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRequest.Size(m)
+}
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
 
-// UserAppClient is the client API for UserApp service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type UserAppClient interface {
-	SignIn(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
-	SignOut(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
-	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
-	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*Empty, error)
-	DeleteUser(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+func (m *ExportRequest) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_JSON
 }
 
-type userAppClient struct {
-	cc *grpc.ClientConn
+type ExportUserDataRequest struct {
+	UserId               string       `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format               ExportFormat `protobuf:"varint,2,opt,name=format,proto3,enum=message.ExportFormat" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
-func NewUserAppClient(cc *grpc.ClientConn) UserAppClient {
-	return &userAppClient{cc}
+func (m *ExportUserDataRequest) Reset()         { *m = ExportUserDataRequest{} }
+func (m *ExportUserDataRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportUserDataRequest) ProtoMessage()    {}
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{7}
 }
 
-func (c *userAppClient) SignIn(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
-	out := new(AuthResponse)
-	err := c.cc.Invoke(ctx, "/message.UserApp/SignIn", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ExportUserDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportUserDataRequest.Unmarshal(m, b)
+}
+func (m *ExportUserDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportUserDataRequest.Marshal(b, m, deterministic)
+}
+func (m *ExportUserDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportUserDataRequest.Merge(m, src)
+}
+func (m *ExportUserDataRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportUserDataRequest.Size(m)
+}
+func (m *ExportUserDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportUserDataRequest.DiscardUnknown(m)
 }
 
-func (c *userAppClient) SignOut(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/message.UserApp/SignOut", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExportUserDataRequest proto.InternalMessageInfo
+
+func (m *ExportUserDataRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *userAppClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
-	out := new(CreateUserResponse)
-	err := c.cc.Invoke(ctx, "/message.UserApp/CreateUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportUserDataRequest) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
 	}
-	return out, nil
+	return ExportFormat_EXPORT_FORMAT_JSON
 }
 
-func (c *userAppClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/message.UserApp/UpdateUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+type ExportChunk struct {
+	// content_type is only set on the first chunk
+	ContentType          string   `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{8}
+}
+
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportChunk.Unmarshal(m, b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+}
+func (m *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(m, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return xxx_messageInfo_ExportChunk.Size(m)
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetContentType() string {
+	if m != nil {
+		return m.ContentType
 	}
-	return out, nil
+	return ""
 }
 
-func (c *userAppClient) DeleteUser(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/message.UserApp/DeleteUser", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-// UserAppServer is the server API for UserApp service.
-type UserAppServer interface {
-	SignIn(context.Context, *AuthRequest) (*AuthResponse, error)
-	SignOut(context.Context, *Empty) (*Empty, error)
-	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
-	UpdateUser(context.Context, *UpdateUserRequest) (*Empty, error)
-	DeleteUser(context.Context, *Empty) (*Empty, error)
+type EraseUserRequest struct {
+	UserId               string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedUserAppServer can be embedded to have forward compatible implementations.
-type UnimplementedUserAppServer struct {
+func (m *EraseUserRequest) Reset()         { *m = EraseUserRequest{} }
+func (m *EraseUserRequest) String() string { return proto.CompactTextString(m) }
+func (*EraseUserRequest) ProtoMessage()    {}
+func (*EraseUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{9}
 }
 
-func (*UnimplementedUserAppServer) SignIn(ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignIn not implemented")
+func (m *EraseUserRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EraseUserRequest.Unmarshal(m, b)
 }
-func (*UnimplementedUserAppServer) SignOut(ctx context.Context, req *Empty) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SignOut not implemented")
+func (m *EraseUserRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EraseUserRequest.Marshal(b, m, deterministic)
 }
-func (*UnimplementedUserAppServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*CreateUserResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+func (m *EraseUserRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EraseUserRequest.Merge(m, src)
 }
-func (*UnimplementedUserAppServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+func (m *EraseUserRequest) XXX_Size() int {
+	return xxx_messageInfo_EraseUserRequest.Size(m)
 }
-func (*UnimplementedUserAppServer) DeleteUser(ctx context.Context, req *Empty) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+func (m *EraseUserRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EraseUserRequest.DiscardUnknown(m)
 }
 
-func RegisterUserAppServer(s *grpc.Server, srv UserAppServer) {
-	s.RegisterService(&_UserApp_serviceDesc, srv)
-}
+var xxx_messageInfo_EraseUserRequest proto.InternalMessageInfo
 
-func _UserApp_SignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UserAppServer).SignIn(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/message.UserApp/SignIn",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserAppServer).SignIn(ctx, req.(*AuthRequest))
+func (m *EraseUserRequest) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _UserApp_SignOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UserAppServer).SignOut(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/message.UserApp/SignOut",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserAppServer).SignOut(ctx, req.(*Empty))
-	}
-	return interceptor(ctx, in, info, handler)
+type ErasureReceipt struct {
+	ReceiptId string `protobuf:"bytes,1,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// trigger is who requested the erasure: user, admin or retention
+	Trigger              string               `protobuf:"bytes,3,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	ErasedAt             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
-func _UserApp_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UserAppServer).CreateUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/message.UserApp/CreateUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserAppServer).CreateUser(ctx, req.(*CreateUserRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *ErasureReceipt) Reset()         { *m = ErasureReceipt{} }
+func (m *ErasureReceipt) String() string { return proto.CompactTextString(m) }
+func (*ErasureReceipt) ProtoMessage()    {}
+func (*ErasureReceipt) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{10}
 }
 
-func _UserApp_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateUserRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(UserAppServer).UpdateUser(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/message.UserApp/UpdateUser",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserAppServer).UpdateUser(ctx, req.(*UpdateUserRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+func (m *ErasureReceipt) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ErasureReceipt.Unmarshal(m, b)
+}
+func (m *ErasureReceipt) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ErasureReceipt.Marshal(b, m, deterministic)
+}
+func (m *ErasureReceipt) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ErasureReceipt.Merge(m, src)
+}
+func (m *ErasureReceipt) XXX_Size() int {
+	return xxx_messageInfo_ErasureReceipt.Size(m)
+}
+func (m *ErasureReceipt) XXX_DiscardUnknown() {
+	xxx_messageInfo_ErasureReceipt.DiscardUnknown(m)
 }
 
-func _UserApp_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_ErasureReceipt proto.InternalMessageInfo
+
+func (m *ErasureReceipt) GetReceiptId() string {
+	if m != nil {
+		return m.ReceiptId
 	}
-	if interceptor == nil {
-		return srv.(UserAppServer).DeleteUser(ctx, in)
+	return ""
+}
+
+func (m *ErasureReceipt) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/message.UserApp/DeleteUser",
+	return ""
+}
+
+func (m *ErasureReceipt) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserAppServer).DeleteUser(ctx, req.(*Empty))
+	return ""
+}
+
+func (m *ErasureReceipt) GetErasedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ErasedAt
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-var _UserApp_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "message.UserApp",
+type ListAuditEventsRequest struct {
+	ActorId      string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	TargetUserId string `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	// page_size defaults to 50 and is capped at 500
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, empty for the first page
+	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsRequest) Reset()         { *m = ListAuditEventsRequest{} }
+func (m *ListAuditEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsRequest) ProtoMessage()    {}
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{11}
+}
+
+func (m *ListAuditEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsRequest.Unmarshal(m, b)
+}
+func (m *ListAuditEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsRequest.Merge(m, src)
+}
+func (m *ListAuditEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsRequest.Size(m)
+}
+func (m *ListAuditEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsRequest proto.InternalMessageInfo
+
+func (m *ListAuditEventsRequest) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetTargetUserId() string {
+	if m != nil {
+		return m.TargetUserId
+	}
+	return ""
+}
+
+func (m *ListAuditEventsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListAuditEventsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	Id           string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Seq          int64  `protobuf:"varint,2,opt,name=seq,proto3" json:"seq,omitempty"`
+	ActorId      string `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	TargetUserId string `protobuf:"bytes,4,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	Action       string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	// changed_fields holds the names of the changed fields, never their values
+	ChangedFields []string             `protobuf:"bytes,6,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	RequestId     string               `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	ClientIp      string               `protobuf:"bytes,8,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	CreatedAt     *timestamp.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// hash is the hex encoded link of the event in the audit chain
+	Hash                 string   `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuditEvent) Reset()         { *m = AuditEvent{} }
+func (m *AuditEvent) String() string { return proto.CompactTextString(m) }
+func (*AuditEvent) ProtoMessage()    {}
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{12}
+}
+
+func (m *AuditEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuditEvent.Unmarshal(m, b)
+}
+func (m *AuditEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuditEvent.Marshal(b, m, deterministic)
+}
+func (m *AuditEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuditEvent.Merge(m, src)
+}
+func (m *AuditEvent) XXX_Size() int {
+	return xxx_messageInfo_AuditEvent.Size(m)
+}
+func (m *AuditEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuditEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuditEvent proto.InternalMessageInfo
+
+func (m *AuditEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetSeq() int64 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *AuditEvent) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetTargetUserId() string {
+	if m != nil {
+		return m.TargetUserId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetAction() string {
+	if m != nil {
+		return m.Action
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetChangedFields() []string {
+	if m != nil {
+		return m.ChangedFields
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetClientIp() string {
+	if m != nil {
+		return m.ClientIp
+	}
+	return ""
+}
+
+func (m *AuditEvent) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *AuditEvent) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	Events []*AuditEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// next_page_token is empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAuditEventsResponse) Reset()         { *m = ListAuditEventsResponse{} }
+func (m *ListAuditEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAuditEventsResponse) ProtoMessage()    {}
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{13}
+}
+
+func (m *ListAuditEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAuditEventsResponse.Unmarshal(m, b)
+}
+func (m *ListAuditEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAuditEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAuditEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAuditEventsResponse.Merge(m, src)
+}
+func (m *ListAuditEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAuditEventsResponse.Size(m)
+}
+func (m *ListAuditEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAuditEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAuditEventsResponse proto.InternalMessageInfo
+
+func (m *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ListAuditEventsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesRequest struct {
+	// status is pending, delivered or dead, it defaults to dead
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// page_size defaults to 50 and is capped at 500
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, empty for the first page
+	PageToken            string   `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesRequest) Reset()         { *m = ListWebhookDeliveriesRequest{} }
+func (m *ListWebhookDeliveriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesRequest) ProtoMessage()    {}
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{14}
+}
+
+func (m *ListWebhookDeliveriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesRequest.Size(m)
+}
+func (m *ListWebhookDeliveriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesRequest proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesRequest) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type WebhookDelivery struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EventId              string               `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType            string               `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	UserId               string               `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Endpoint             string               `protobuf:"bytes,5,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Status               string               `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts             int32                `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError            string               `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt        *timestamp.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	DeliveredAt          *timestamp.Timestamp `protobuf:"bytes,10,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *WebhookDelivery) Reset()         { *m = WebhookDelivery{} }
+func (m *WebhookDelivery) String() string { return proto.CompactTextString(m) }
+func (*WebhookDelivery) ProtoMessage()    {}
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{15}
+}
+
+func (m *WebhookDelivery) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WebhookDelivery.Unmarshal(m, b)
+}
+func (m *WebhookDelivery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WebhookDelivery.Marshal(b, m, deterministic)
+}
+func (m *WebhookDelivery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WebhookDelivery.Merge(m, src)
+}
+func (m *WebhookDelivery) XXX_Size() int {
+	return xxx_messageInfo_WebhookDelivery.Size(m)
+}
+func (m *WebhookDelivery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WebhookDelivery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WebhookDelivery proto.InternalMessageInfo
+
+func (m *WebhookDelivery) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventId() string {
+	if m != nil {
+		return m.EventId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEventType() string {
+	if m != nil {
+		return m.EventType
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetAttempts() int32 {
+	if m != nil {
+		return m.Attempts
+	}
+	return 0
+}
+
+func (m *WebhookDelivery) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
+func (m *WebhookDelivery) GetNextAttemptAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.NextAttemptAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetDeliveredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DeliveredAt
+	}
+	return nil
+}
+
+func (m *WebhookDelivery) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesResponse struct {
+	Deliveries []*WebhookDelivery `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	// next_page_token is empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListWebhookDeliveriesResponse) Reset()         { *m = ListWebhookDeliveriesResponse{} }
+func (m *ListWebhookDeliveriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListWebhookDeliveriesResponse) ProtoMessage()    {}
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{16}
+}
+
+func (m *ListWebhookDeliveriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Unmarshal(m, b)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.Merge(m, src)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListWebhookDeliveriesResponse.Size(m)
+}
+func (m *ListWebhookDeliveriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListWebhookDeliveriesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListWebhookDeliveriesResponse proto.InternalMessageInfo
+
+func (m *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if m != nil {
+		return m.Deliveries
+	}
+	return nil
+}
+
+func (m *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type ReplayWebhookDeliveryRequest struct {
+	DeliveryId           string   `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReplayWebhookDeliveryRequest) Reset()         { *m = ReplayWebhookDeliveryRequest{} }
+func (m *ReplayWebhookDeliveryRequest) String() string { return proto.CompactTextString(m) }
+func (*ReplayWebhookDeliveryRequest) ProtoMessage()    {}
+func (*ReplayWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{17}
+}
+
+func (m *ReplayWebhookDeliveryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReplayWebhookDeliveryRequest.Unmarshal(m, b)
+}
+func (m *ReplayWebhookDeliveryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReplayWebhookDeliveryRequest.Marshal(b, m, deterministic)
+}
+func (m *ReplayWebhookDeliveryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReplayWebhookDeliveryRequest.Merge(m, src)
+}
+func (m *ReplayWebhookDeliveryRequest) XXX_Size() int {
+	return xxx_messageInfo_ReplayWebhookDeliveryRequest.Size(m)
+}
+func (m *ReplayWebhookDeliveryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReplayWebhookDeliveryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReplayWebhookDeliveryRequest proto.InternalMessageInfo
+
+func (m *ReplayWebhookDeliveryRequest) GetDeliveryId() string {
+	if m != nil {
+		return m.DeliveryId
+	}
+	return ""
+}
+
+type WatchUserEventsRequest struct {
+	// cursor is the cursor of the last event received, empty to only receive the new events and "0" to receive every
+	// event still in the outbox
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// types filters the events, like user.created, every event is sent when empty
+	Types                []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchUserEventsRequest) Reset()         { *m = WatchUserEventsRequest{} }
+func (m *WatchUserEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchUserEventsRequest) ProtoMessage()    {}
+func (*WatchUserEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{18}
+}
+
+func (m *WatchUserEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchUserEventsRequest.Unmarshal(m, b)
+}
+func (m *WatchUserEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchUserEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchUserEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchUserEventsRequest.Merge(m, src)
+}
+func (m *WatchUserEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchUserEventsRequest.Size(m)
+}
+func (m *WatchUserEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchUserEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchUserEventsRequest proto.InternalMessageInfo
+
+func (m *WatchUserEventsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *WatchUserEventsRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+type UserEvent struct {
+	// cursor resumes the stream after this event
+	Cursor  string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Id      string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Type    string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	UserId  string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ActorId string `protobuf:"bytes,5,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	// changed_columns holds the names of the changed columns, never their values
+	ChangedColumns       []string             `protobuf:"bytes,6,rep,name=changed_columns,json=changedColumns,proto3" json:"changed_columns,omitempty"`
+	OccurredAt           *timestamp.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UserEvent) Reset()         { *m = UserEvent{} }
+func (m *UserEvent) String() string { return proto.CompactTextString(m) }
+func (*UserEvent) ProtoMessage()    {}
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{19}
+}
+
+func (m *UserEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserEvent.Unmarshal(m, b)
+}
+func (m *UserEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserEvent.Marshal(b, m, deterministic)
+}
+func (m *UserEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserEvent.Merge(m, src)
+}
+func (m *UserEvent) XXX_Size() int {
+	return xxx_messageInfo_UserEvent.Size(m)
+}
+func (m *UserEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserEvent proto.InternalMessageInfo
+
+func (m *UserEvent) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *UserEvent) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *UserEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *UserEvent) GetUserId() string {
+	if m != nil {
+		return m.UserId
+	}
+	return ""
+}
+
+func (m *UserEvent) GetActorId() string {
+	if m != nil {
+		return m.ActorId
+	}
+	return ""
+}
+
+func (m *UserEvent) GetChangedColumns() []string {
+	if m != nil {
+		return m.ChangedColumns
+	}
+	return nil
+}
+
+func (m *UserEvent) GetOccurredAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.OccurredAt
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// scopes are read, write or admin, admin can only be granted by a superuser
+	Scopes []string `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// expires_at is optional, the key never expires when it is not set
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateAPIKeyRequest) Reset()         { *m = CreateAPIKeyRequest{} }
+func (m *CreateAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyRequest) ProtoMessage()    {}
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{20}
+}
+
+func (m *CreateAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAPIKeyRequest.Unmarshal(m, b)
+}
+func (m *CreateAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAPIKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyRequest.Merge(m, src)
+}
+func (m *CreateAPIKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateAPIKeyRequest.Size(m)
+}
+func (m *CreateAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAPIKeyRequest proto.InternalMessageInfo
+
+func (m *CreateAPIKeyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateAPIKeyRequest) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *CreateAPIKeyRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type APIKey struct {
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// prefix is the visible start of the key, it tells the keys apart
+	Prefix    string               `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Scopes    []string             `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// last_used_at is recorded with a resolution of a minute
+	LastUsedAt           *timestamp.Timestamp `protobuf:"bytes,6,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *APIKey) Reset()         { *m = APIKey{} }
+func (m *APIKey) String() string { return proto.CompactTextString(m) }
+func (*APIKey) ProtoMessage()    {}
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{21}
+}
+
+func (m *APIKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_APIKey.Unmarshal(m, b)
+}
+func (m *APIKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_APIKey.Marshal(b, m, deterministic)
+}
+func (m *APIKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_APIKey.Merge(m, src)
+}
+func (m *APIKey) XXX_Size() int {
+	return xxx_messageInfo_APIKey.Size(m)
+}
+func (m *APIKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_APIKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_APIKey proto.InternalMessageInfo
+
+func (m *APIKey) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *APIKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *APIKey) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *APIKey) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *APIKey) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetLastUsedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	ApiKey *APIKey `protobuf:"bytes,1,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// key is the bearer token of the API key, it is only returned once
+	Key                  string   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateAPIKeyResponse) Reset()         { *m = CreateAPIKeyResponse{} }
+func (m *CreateAPIKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateAPIKeyResponse) ProtoMessage()    {}
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{22}
+}
+
+func (m *CreateAPIKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateAPIKeyResponse.Unmarshal(m, b)
+}
+func (m *CreateAPIKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateAPIKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateAPIKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateAPIKeyResponse.Merge(m, src)
+}
+func (m *CreateAPIKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateAPIKeyResponse.Size(m)
+}
+func (m *CreateAPIKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateAPIKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateAPIKeyResponse proto.InternalMessageInfo
+
+func (m *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if m != nil {
+		return m.ApiKey
+	}
+	return nil
+}
+
+func (m *CreateAPIKeyResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type ListAPIKeysResponse struct {
+	ApiKeys              []*APIKey `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListAPIKeysResponse) Reset()         { *m = ListAPIKeysResponse{} }
+func (m *ListAPIKeysResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAPIKeysResponse) ProtoMessage()    {}
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{23}
+}
+
+func (m *ListAPIKeysResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAPIKeysResponse.Unmarshal(m, b)
+}
+func (m *ListAPIKeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAPIKeysResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAPIKeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAPIKeysResponse.Merge(m, src)
+}
+func (m *ListAPIKeysResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAPIKeysResponse.Size(m)
+}
+func (m *ListAPIKeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAPIKeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAPIKeysResponse proto.InternalMessageInfo
+
+func (m *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if m != nil {
+		return m.ApiKeys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	KeyId                string   `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeAPIKeyRequest) Reset()         { *m = RevokeAPIKeyRequest{} }
+func (m *RevokeAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeAPIKeyRequest) ProtoMessage()    {}
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{24}
+}
+
+func (m *RevokeAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeAPIKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeAPIKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeAPIKeyRequest.Merge(m, src)
+}
+func (m *RevokeAPIKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeAPIKeyRequest.Size(m)
+}
+func (m *RevokeAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeAPIKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeAPIKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type CreateServiceAccountRequest struct {
+	// name is unique among the usernames, the service accounts have no email nor password
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Superuser bool   `protobuf:"varint,2,opt,name=superuser,proto3" json:"superuser,omitempty"`
+	// certificate_san is optional, the clients presenting a verified certificate having this SAN are authenticated as
+	// the service account
+	CertificateSan       string   `protobuf:"bytes,3,opt,name=certificate_san,json=certificateSan,proto3" json:"certificate_san,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateServiceAccountRequest) Reset()         { *m = CreateServiceAccountRequest{} }
+func (m *CreateServiceAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateServiceAccountRequest) ProtoMessage()    {}
+func (*CreateServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{25}
+}
+
+func (m *CreateServiceAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateServiceAccountRequest.Unmarshal(m, b)
+}
+func (m *CreateServiceAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateServiceAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateServiceAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateServiceAccountRequest.Merge(m, src)
+}
+func (m *CreateServiceAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateServiceAccountRequest.Size(m)
+}
+func (m *CreateServiceAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateServiceAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateServiceAccountRequest proto.InternalMessageInfo
+
+func (m *CreateServiceAccountRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateServiceAccountRequest) GetSuperuser() bool {
+	if m != nil {
+		return m.Superuser
+	}
+	return false
+}
+
+func (m *CreateServiceAccountRequest) GetCertificateSan() string {
+	if m != nil {
+		return m.CertificateSan
+	}
+	return ""
+}
+
+type ServiceAccount struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Superuser            bool                 `protobuf:"varint,3,opt,name=superuser,proto3" json:"superuser,omitempty"`
+	CertificateSan       string               `protobuf:"bytes,4,opt,name=certificate_san,json=certificateSan,proto3" json:"certificate_san,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ServiceAccount) Reset()         { *m = ServiceAccount{} }
+func (m *ServiceAccount) String() string { return proto.CompactTextString(m) }
+func (*ServiceAccount) ProtoMessage()    {}
+func (*ServiceAccount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{26}
+}
+
+func (m *ServiceAccount) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceAccount.Unmarshal(m, b)
+}
+func (m *ServiceAccount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceAccount.Marshal(b, m, deterministic)
+}
+func (m *ServiceAccount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceAccount.Merge(m, src)
+}
+func (m *ServiceAccount) XXX_Size() int {
+	return xxx_messageInfo_ServiceAccount.Size(m)
+}
+func (m *ServiceAccount) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceAccount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceAccount proto.InternalMessageInfo
+
+func (m *ServiceAccount) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *ServiceAccount) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ServiceAccount) GetSuperuser() bool {
+	if m != nil {
+		return m.Superuser
+	}
+	return false
+}
+
+func (m *ServiceAccount) GetCertificateSan() string {
+	if m != nil {
+		return m.CertificateSan
+	}
+	return ""
+}
+
+func (m *ServiceAccount) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+type ListServiceAccountsRequest struct {
+	// page_size defaults to 50 and is capped at 500
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, empty for the first page
+	PageToken            string   `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListServiceAccountsRequest) Reset()         { *m = ListServiceAccountsRequest{} }
+func (m *ListServiceAccountsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListServiceAccountsRequest) ProtoMessage()    {}
+func (*ListServiceAccountsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{27}
+}
+
+func (m *ListServiceAccountsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServiceAccountsRequest.Unmarshal(m, b)
+}
+func (m *ListServiceAccountsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServiceAccountsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListServiceAccountsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServiceAccountsRequest.Merge(m, src)
+}
+func (m *ListServiceAccountsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListServiceAccountsRequest.Size(m)
+}
+func (m *ListServiceAccountsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServiceAccountsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServiceAccountsRequest proto.InternalMessageInfo
+
+func (m *ListServiceAccountsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *ListServiceAccountsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type ListServiceAccountsResponse struct {
+	ServiceAccounts []*ServiceAccount `protobuf:"bytes,1,rep,name=service_accounts,json=serviceAccounts,proto3" json:"service_accounts,omitempty"`
+	// next_page_token is empty on the last page
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListServiceAccountsResponse) Reset()         { *m = ListServiceAccountsResponse{} }
+func (m *ListServiceAccountsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListServiceAccountsResponse) ProtoMessage()    {}
+func (*ListServiceAccountsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{28}
+}
+
+func (m *ListServiceAccountsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServiceAccountsResponse.Unmarshal(m, b)
+}
+func (m *ListServiceAccountsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServiceAccountsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListServiceAccountsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServiceAccountsResponse.Merge(m, src)
+}
+func (m *ListServiceAccountsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListServiceAccountsResponse.Size(m)
+}
+func (m *ListServiceAccountsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServiceAccountsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServiceAccountsResponse proto.InternalMessageInfo
+
+func (m *ListServiceAccountsResponse) GetServiceAccounts() []*ServiceAccount {
+	if m != nil {
+		return m.ServiceAccounts
+	}
+	return nil
+}
+
+func (m *ListServiceAccountsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type DeleteServiceAccountRequest struct {
+	ServiceAccountId     string   `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteServiceAccountRequest) Reset()         { *m = DeleteServiceAccountRequest{} }
+func (m *DeleteServiceAccountRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteServiceAccountRequest) ProtoMessage()    {}
+func (*DeleteServiceAccountRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{29}
+}
+
+func (m *DeleteServiceAccountRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteServiceAccountRequest.Unmarshal(m, b)
+}
+func (m *DeleteServiceAccountRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteServiceAccountRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteServiceAccountRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteServiceAccountRequest.Merge(m, src)
+}
+func (m *DeleteServiceAccountRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteServiceAccountRequest.Size(m)
+}
+func (m *DeleteServiceAccountRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteServiceAccountRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteServiceAccountRequest proto.InternalMessageInfo
+
+func (m *DeleteServiceAccountRequest) GetServiceAccountId() string {
+	if m != nil {
+		return m.ServiceAccountId
+	}
+	return ""
+}
+
+type CreateServiceAccountAPIKeyRequest struct {
+	ServiceAccountId string `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	Name             string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// scopes are read, write or admin, admin can only be granted to a superuser service account
+	Scopes []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// expires_at is optional, the key never expires when it is not set
+	ExpiresAt            *timestamp.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CreateServiceAccountAPIKeyRequest) Reset()         { *m = CreateServiceAccountAPIKeyRequest{} }
+func (m *CreateServiceAccountAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateServiceAccountAPIKeyRequest) ProtoMessage()    {}
+func (*CreateServiceAccountAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{30}
+}
+
+func (m *CreateServiceAccountAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateServiceAccountAPIKeyRequest.Unmarshal(m, b)
+}
+func (m *CreateServiceAccountAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateServiceAccountAPIKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateServiceAccountAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateServiceAccountAPIKeyRequest.Merge(m, src)
+}
+func (m *CreateServiceAccountAPIKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateServiceAccountAPIKeyRequest.Size(m)
+}
+func (m *CreateServiceAccountAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateServiceAccountAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateServiceAccountAPIKeyRequest proto.InternalMessageInfo
+
+func (m *CreateServiceAccountAPIKeyRequest) GetServiceAccountId() string {
+	if m != nil {
+		return m.ServiceAccountId
+	}
+	return ""
+}
+
+func (m *CreateServiceAccountAPIKeyRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CreateServiceAccountAPIKeyRequest) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *CreateServiceAccountAPIKeyRequest) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+type ListServiceAccountAPIKeysRequest struct {
+	ServiceAccountId     string   `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListServiceAccountAPIKeysRequest) Reset()         { *m = ListServiceAccountAPIKeysRequest{} }
+func (m *ListServiceAccountAPIKeysRequest) String() string { return proto.CompactTextString(m) }
+func (*ListServiceAccountAPIKeysRequest) ProtoMessage()    {}
+func (*ListServiceAccountAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{31}
+}
+
+func (m *ListServiceAccountAPIKeysRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListServiceAccountAPIKeysRequest.Unmarshal(m, b)
+}
+func (m *ListServiceAccountAPIKeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListServiceAccountAPIKeysRequest.Marshal(b, m, deterministic)
+}
+func (m *ListServiceAccountAPIKeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListServiceAccountAPIKeysRequest.Merge(m, src)
+}
+func (m *ListServiceAccountAPIKeysRequest) XXX_Size() int {
+	return xxx_messageInfo_ListServiceAccountAPIKeysRequest.Size(m)
+}
+func (m *ListServiceAccountAPIKeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListServiceAccountAPIKeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListServiceAccountAPIKeysRequest proto.InternalMessageInfo
+
+func (m *ListServiceAccountAPIKeysRequest) GetServiceAccountId() string {
+	if m != nil {
+		return m.ServiceAccountId
+	}
+	return ""
+}
+
+type RevokeServiceAccountAPIKeyRequest struct {
+	ServiceAccountId     string   `protobuf:"bytes,1,opt,name=service_account_id,json=serviceAccountId,proto3" json:"service_account_id,omitempty"`
+	KeyId                string   `protobuf:"bytes,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokeServiceAccountAPIKeyRequest) Reset()         { *m = RevokeServiceAccountAPIKeyRequest{} }
+func (m *RevokeServiceAccountAPIKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeServiceAccountAPIKeyRequest) ProtoMessage()    {}
+func (*RevokeServiceAccountAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{32}
+}
+
+func (m *RevokeServiceAccountAPIKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeServiceAccountAPIKeyRequest.Unmarshal(m, b)
+}
+func (m *RevokeServiceAccountAPIKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeServiceAccountAPIKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeServiceAccountAPIKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeServiceAccountAPIKeyRequest.Merge(m, src)
+}
+func (m *RevokeServiceAccountAPIKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeServiceAccountAPIKeyRequest.Size(m)
+}
+func (m *RevokeServiceAccountAPIKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeServiceAccountAPIKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeServiceAccountAPIKeyRequest proto.InternalMessageInfo
+
+func (m *RevokeServiceAccountAPIKeyRequest) GetServiceAccountId() string {
+	if m != nil {
+		return m.ServiceAccountId
+	}
+	return ""
+}
+
+func (m *RevokeServiceAccountAPIKeyRequest) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+type Empty struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ebceca9e8703e37f, []int{33}
+}
+
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Empty.Unmarshal(m, b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return xxx_messageInfo_Empty.Size(m)
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("message.ExportFormat", ExportFormat_name, ExportFormat_value)
+	proto.RegisterType((*AuthRequest)(nil), "message.AuthRequest")
+	proto.RegisterType((*AuthResponse)(nil), "message.AuthResponse")
+	proto.RegisterType((*CreateUserRequest)(nil), "message.CreateUserRequest")
+	proto.RegisterType((*CreateUserResponse)(nil), "message.CreateUserResponse")
+	proto.RegisterType((*UpdateUserRequest)(nil), "message.UpdateUserRequest")
+	proto.RegisterType((*RestoreUserRequest)(nil), "message.RestoreUserRequest")
+	proto.RegisterType((*ExportRequest)(nil), "message.ExportRequest")
+	proto.RegisterType((*ExportUserDataRequest)(nil), "message.ExportUserDataRequest")
+	proto.RegisterType((*ExportChunk)(nil), "message.ExportChunk")
+	proto.RegisterType((*EraseUserRequest)(nil), "message.EraseUserRequest")
+	proto.RegisterType((*ErasureReceipt)(nil), "message.ErasureReceipt")
+	proto.RegisterType((*ListAuditEventsRequest)(nil), "message.ListAuditEventsRequest")
+	proto.RegisterType((*AuditEvent)(nil), "message.AuditEvent")
+	proto.RegisterType((*ListAuditEventsResponse)(nil), "message.ListAuditEventsResponse")
+	proto.RegisterType((*ListWebhookDeliveriesRequest)(nil), "message.ListWebhookDeliveriesRequest")
+	proto.RegisterType((*WebhookDelivery)(nil), "message.WebhookDelivery")
+	proto.RegisterType((*ListWebhookDeliveriesResponse)(nil), "message.ListWebhookDeliveriesResponse")
+	proto.RegisterType((*ReplayWebhookDeliveryRequest)(nil), "message.ReplayWebhookDeliveryRequest")
+	proto.RegisterType((*WatchUserEventsRequest)(nil), "message.WatchUserEventsRequest")
+	proto.RegisterType((*UserEvent)(nil), "message.UserEvent")
+	proto.RegisterType((*CreateAPIKeyRequest)(nil), "message.CreateAPIKeyRequest")
+	proto.RegisterType((*APIKey)(nil), "message.APIKey")
+	proto.RegisterType((*CreateAPIKeyResponse)(nil), "message.CreateAPIKeyResponse")
+	proto.RegisterType((*ListAPIKeysResponse)(nil), "message.ListAPIKeysResponse")
+	proto.RegisterType((*RevokeAPIKeyRequest)(nil), "message.RevokeAPIKeyRequest")
+	proto.RegisterType((*CreateServiceAccountRequest)(nil), "message.CreateServiceAccountRequest")
+	proto.RegisterType((*ServiceAccount)(nil), "message.ServiceAccount")
+	proto.RegisterType((*ListServiceAccountsRequest)(nil), "message.ListServiceAccountsRequest")
+	proto.RegisterType((*ListServiceAccountsResponse)(nil), "message.ListServiceAccountsResponse")
+	proto.RegisterType((*DeleteServiceAccountRequest)(nil), "message.DeleteServiceAccountRequest")
+	proto.RegisterType((*CreateServiceAccountAPIKeyRequest)(nil), "message.CreateServiceAccountAPIKeyRequest")
+	proto.RegisterType((*ListServiceAccountAPIKeysRequest)(nil), "message.ListServiceAccountAPIKeysRequest")
+	proto.RegisterType((*RevokeServiceAccountAPIKeyRequest)(nil), "message.RevokeServiceAccountAPIKeyRequest")
+	proto.RegisterType((*Empty)(nil), "message.Empty")
+}
+
+func init() { proto.RegisterFile("message/message.proto", fileDescriptor_ebceca9e8703e37f) }
+
+var fileDescriptor_ebceca9e8703e37f = []byte{
+	// 2090 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x6f, 0x1b, 0x59,
+	0x15, 0x67, 0x6c, 0xc7, 0x1f, 0xc7, 0xae, 0x93, 0xde, 0xc4, 0x8e, 0x33, 0x49, 0xdb, 0x74, 0xe8,
+	0x76, 0xb3, 0xee, 0x26, 0xee, 0x06, 0xc4, 0xc2, 0x2e, 0x05, 0xb9, 0x6d, 0x8a, 0xcc, 0xd2, 0x6d,
+	0x76, 0x92, 0x6a, 0xab, 0xed, 0x83, 0x75, 0xeb, 0xb9, 0x71, 0x46, 0xb1, 0x67, 0x66, 0x67, 0xae,
+	0xb3, 0xf1, 0x96, 0x82, 0xe0, 0x01, 0x89, 0xb7, 0x95, 0x10, 0x4f, 0x08, 0x09, 0x5e, 0xf8, 0x13,
+	0x10, 0xfc, 0x1d, 0xbc, 0x23, 0x21, 0xf1, 0x87, 0xa0, 0xfb, 0x31, 0x9f, 0x9e, 0xb1, 0x93, 0x8a,
+	0x7d, 0xf2, 0xdc, 0x33, 0x67, 0xce, 0xe7, 0xef, 0x9c, 0x73, 0x7d, 0xa0, 0x31, 0x26, 0x9e, 0x87,
+	0x87, 0xa4, 0x23, 0x7f, 0xf7, 0x1c, 0xd7, 0xa6, 0x36, 0x2a, 0xc9, 0xa3, 0xba, 0x35, 0xb4, 0xed,
+	0xe1, 0x88, 0x74, 0xb0, 0x63, 0x76, 0xb0, 0x65, 0xd9, 0x14, 0x53, 0xd3, 0xb6, 0x3c, 0xc1, 0xa6,
+	0xde, 0x92, 0x6f, 0xf9, 0xe9, 0xd5, 0xe4, 0xa4, 0x43, 0xcd, 0x31, 0xf1, 0x28, 0x1e, 0x3b, 0x82,
+	0x41, 0x7b, 0x09, 0xd5, 0xee, 0x84, 0x9e, 0xea, 0xe4, 0xcb, 0x09, 0xf1, 0x28, 0x52, 0xa1, 0x3c,
+	0xf1, 0x88, 0x6b, 0xe1, 0x31, 0x69, 0x29, 0xdb, 0xca, 0x4e, 0x45, 0x0f, 0xce, 0x68, 0x0d, 0x96,
+	0xc8, 0x18, 0x9b, 0xa3, 0x56, 0x8e, 0xbf, 0x10, 0x07, 0xf6, 0x85, 0x83, 0x3d, 0xef, 0x2b, 0xdb,
+	0x35, 0x5a, 0x79, 0xf1, 0x85, 0x7f, 0xd6, 0x4c, 0xa8, 0x09, 0xe1, 0x9e, 0x63, 0x5b, 0x1e, 0x59,
+	0x24, 0x9d, 0xda, 0x67, 0xc4, 0xf2, 0xa5, 0xf3, 0x03, 0x7a, 0x17, 0x96, 0x1d, 0x62, 0x19, 0xa6,
+	0x35, 0xec, 0xbb, 0xc4, 0xa3, 0xb6, 0x4b, 0xb8, 0x92, 0xb2, 0x5e, 0x97, 0x64, 0x5d, 0x50, 0x35,
+	0x0c, 0xd7, 0x1f, 0xb9, 0x04, 0x53, 0xf2, 0xdc, 0x23, 0xee, 0xb7, 0xe3, 0xcd, 0x2e, 0xa0, 0xa8,
+	0x0a, 0xe9, 0xd3, 0x3a, 0x94, 0x98, 0xcc, 0xbe, 0x69, 0x48, 0x15, 0x45, 0x76, 0xec, 0x19, 0xda,
+	0x01, 0x5c, 0x7f, 0xee, 0x18, 0x73, 0x2c, 0xca, 0x65, 0x59, 0x94, 0x8f, 0x58, 0xc4, 0xb4, 0x4a,
+	0x1f, 0xa3, 0x72, 0x32, 0xb5, 0xfe, 0x04, 0xae, 0x1d, 0x5c, 0x38, 0xb6, 0x4b, 0x7d, 0xce, 0x5d,
+	0x28, 0x9e, 0xd8, 0xee, 0x18, 0x53, 0xce, 0x58, 0xdf, 0x6f, 0xec, 0xf9, 0x40, 0x12, 0x7c, 0x4f,
+	0xf8, 0x4b, 0x5d, 0x32, 0x69, 0x7d, 0x68, 0x08, 0x3a, 0xd3, 0xf6, 0x18, 0x53, 0xbc, 0x48, 0x63,
+	0x44, 0x41, 0xee, 0x32, 0x0a, 0x1e, 0x43, 0x55, 0xd0, 0x1f, 0x9d, 0x4e, 0xac, 0x33, 0x74, 0x1b,
+	0x6a, 0x03, 0xdb, 0xa2, 0xc4, 0xa2, 0x7d, 0x3a, 0x75, 0xfc, 0x34, 0x55, 0x25, 0xed, 0x78, 0xea,
+	0x10, 0x84, 0xa0, 0x60, 0x60, 0x8a, 0xb9, 0xf8, 0x9a, 0xce, 0x9f, 0xb5, 0x7b, 0xb0, 0x72, 0xe0,
+	0x62, 0xef, 0x72, 0x31, 0xf9, 0x93, 0x02, 0x75, 0xc6, 0x3d, 0x71, 0x89, 0x4e, 0x06, 0xc4, 0x74,
+	0x28, 0xba, 0x01, 0xe0, 0x8a, 0xc7, 0x90, 0xbd, 0x22, 0x29, 0x3d, 0x23, 0x2a, 0x2a, 0x17, 0x73,
+	0xb6, 0x05, 0x25, 0xea, 0x9a, 0xc3, 0x21, 0x71, 0x65, 0x96, 0xfc, 0x23, 0xfa, 0x10, 0x2a, 0x84,
+	0x59, 0x64, 0xf4, 0x31, 0x6d, 0x15, 0xb6, 0x95, 0x9d, 0xea, 0xbe, 0xba, 0x27, 0xaa, 0x6f, 0xcf,
+	0xaf, 0xbe, 0xbd, 0x63, 0xbf, 0xfa, 0xf4, 0xb2, 0x60, 0xee, 0x52, 0xed, 0x8f, 0x0a, 0x34, 0x7f,
+	0x61, 0x7a, 0xb4, 0x3b, 0x31, 0x4c, 0x7a, 0x70, 0x4e, 0x2c, 0xea, 0xf9, 0x1e, 0x6d, 0x40, 0x19,
+	0x0f, 0xa8, 0x1d, 0x71, 0xa9, 0xc4, 0xcf, 0x3d, 0x03, 0xdd, 0x81, 0x3a, 0xc5, 0xee, 0x90, 0xd0,
+	0x7e, 0xdc, 0xd0, 0x9a, 0xa0, 0x3e, 0x17, 0xe6, 0x6e, 0x42, 0xc5, 0xc1, 0x43, 0xd2, 0xf7, 0xcc,
+	0xaf, 0x45, 0xe1, 0x2c, 0x31, 0x3c, 0x0f, 0xc9, 0x91, 0xf9, 0x35, 0x61, 0x31, 0xe0, 0x2f, 0x45,
+	0xd9, 0x15, 0x44, 0x0c, 0x18, 0xe5, 0x98, 0x11, 0xb4, 0x7f, 0xe6, 0x00, 0x42, 0x9b, 0x50, 0x1d,
+	0x72, 0x81, 0x15, 0x39, 0xd3, 0x40, 0x2b, 0x90, 0xf7, 0xc8, 0x97, 0x5c, 0x6b, 0x5e, 0x67, 0x8f,
+	0x31, 0x6b, 0xf3, 0x8b, 0xac, 0x2d, 0xa4, 0x58, 0xdb, 0x84, 0x22, 0x1e, 0xb0, 0xee, 0xd5, 0x5a,
+	0x12, 0x41, 0x17, 0x27, 0xf4, 0x0e, 0xd4, 0x07, 0xa7, 0xd8, 0x1a, 0x12, 0xa3, 0x7f, 0x62, 0x92,
+	0x91, 0xe1, 0xb5, 0x8a, 0xdb, 0xf9, 0x9d, 0x8a, 0x7e, 0x4d, 0x52, 0x9f, 0x70, 0xa2, 0xc8, 0x29,
+	0x0f, 0x1c, 0x53, 0x50, 0xf2, 0x73, 0xca, 0x29, 0x22, 0x16, 0x83, 0x91, 0xc9, 0x80, 0x66, 0x3a,
+	0xad, 0xb2, 0xa8, 0x3d, 0x41, 0xe8, 0x39, 0xe8, 0x47, 0x00, 0x03, 0x5e, 0xdb, 0x3c, 0x7d, 0x95,
+	0x85, 0xe9, 0xab, 0x48, 0xee, 0x2e, 0x65, 0xf0, 0x3c, 0xc5, 0xde, 0x69, 0x0b, 0xb8, 0x48, 0xfe,
+	0xac, 0x59, 0xb0, 0x3e, 0x93, 0x52, 0xd9, 0x2f, 0xee, 0x41, 0x91, 0x70, 0x4a, 0x4b, 0xd9, 0xce,
+	0xef, 0x54, 0xf7, 0x57, 0x83, 0x72, 0x09, 0xb9, 0x75, 0xc9, 0x82, 0xee, 0xc2, 0xb2, 0x45, 0x2e,
+	0x68, 0x3f, 0x92, 0x27, 0x91, 0xe6, 0x6b, 0x8c, 0x7c, 0x18, 0xe4, 0xca, 0x85, 0x2d, 0xa6, 0xef,
+	0x73, 0xf2, 0xea, 0xd4, 0xb6, 0xcf, 0x1e, 0x93, 0x91, 0x79, 0x4e, 0x5c, 0x93, 0x04, 0x40, 0x6a,
+	0x42, 0xd1, 0xa3, 0x98, 0x4e, 0x3c, 0xbf, 0x32, 0xc4, 0x29, 0x8e, 0x8f, 0xdc, 0x5c, 0x7c, 0xe4,
+	0x93, 0xf8, 0xf8, 0x6b, 0x1e, 0x96, 0xe3, 0x0a, 0xa7, 0x33, 0x20, 0xd9, 0x80, 0x32, 0xf7, 0x24,
+	0xc4, 0x67, 0x89, 0x9f, 0x7b, 0x06, 0x93, 0x4e, 0xce, 0x83, 0xb2, 0x97, 0xd2, 0xc9, 0xb9, 0x5f,
+	0xf4, 0x91, 0x0a, 0x2c, 0xc4, 0x2a, 0x50, 0x85, 0x32, 0xb1, 0x0c, 0xc7, 0x36, 0x2d, 0x2a, 0x61,
+	0x12, 0x9c, 0x23, 0x6e, 0x16, 0x63, 0x6e, 0xaa, 0x50, 0xc6, 0x94, 0x92, 0xb1, 0x43, 0x3d, 0x8e,
+	0x8b, 0x25, 0x3d, 0x38, 0x33, 0x3b, 0x46, 0xd8, 0xa3, 0x7d, 0xe2, 0xba, 0xb6, 0x2b, 0x71, 0x51,
+	0x61, 0x94, 0x03, 0x46, 0x40, 0x0f, 0x65, 0x06, 0x24, 0xff, 0xe5, 0xd0, 0xc1, 0xb3, 0xd3, 0x15,
+	0x5f, 0x74, 0x29, 0x7a, 0x00, 0x35, 0x43, 0x44, 0x48, 0xc0, 0x0b, 0x16, 0x0a, 0xa8, 0x06, 0xfc,
+	0x5d, 0x9a, 0xc0, 0x66, 0xf5, 0x0a, 0xd8, 0xd4, 0x7e, 0xa3, 0xc0, 0x8d, 0x0c, 0x60, 0x48, 0x38,
+	0xfe, 0x10, 0xc0, 0x08, 0xa8, 0x12, 0x92, 0xad, 0x00, 0x92, 0x89, 0xfc, 0xea, 0x11, 0xde, 0x4b,
+	0x63, 0xf3, 0xa7, 0xb0, 0xa5, 0x13, 0x67, 0x84, 0xa7, 0x49, 0x61, 0x12, 0x9b, 0xb7, 0xc0, 0xf7,
+	0x76, 0x1a, 0xf6, 0x39, 0x5f, 0xd1, 0xb4, 0x67, 0x68, 0x4f, 0xa0, 0xf9, 0x39, 0xa6, 0x83, 0x53,
+	0xd6, 0x25, 0xe2, 0xfd, 0xb1, 0x09, 0xc5, 0xc1, 0xc4, 0xf5, 0x6c, 0xd7, 0x87, 0xb5, 0x38, 0xf1,
+	0xbb, 0xc4, 0xd4, 0x21, 0x5e, 0x2b, 0xc7, 0xfb, 0x84, 0x38, 0x68, 0xff, 0x51, 0xa0, 0x12, 0xc8,
+	0xc8, 0xfc, 0x56, 0x40, 0x38, 0x17, 0x40, 0x18, 0x41, 0x21, 0x82, 0xd0, 0x02, 0x9d, 0x0b, 0xce,
+	0x68, 0x0b, 0x5c, 0x8a, 0xb7, 0xc0, 0x77, 0x61, 0xd9, 0x6f, 0x62, 0x03, 0x7b, 0x34, 0x19, 0x5b,
+	0x7e, 0x17, 0xf3, 0x7b, 0xdb, 0x23, 0x41, 0x45, 0x1f, 0x43, 0xd5, 0x1e, 0x0c, 0x26, 0xae, 0x04,
+	0x4b, 0x69, 0x61, 0xbe, 0xc1, 0x67, 0xef, 0x52, 0xed, 0x97, 0xb0, 0x2a, 0xee, 0x28, 0xdd, 0xc3,
+	0xde, 0x27, 0x24, 0x88, 0x31, 0x82, 0x42, 0xe4, 0x12, 0xc4, 0x9f, 0x79, 0xb1, 0x0c, 0xec, 0x30,
+	0x4a, 0xf2, 0xc4, 0xe0, 0x46, 0x2e, 0x1c, 0xd3, 0x25, 0x1e, 0x53, 0x9f, 0x5f, 0x0c, 0x37, 0xc9,
+	0xdd, 0xa5, 0xda, 0x37, 0x39, 0x28, 0x0a, 0xc5, 0x33, 0x9d, 0xc0, 0xb7, 0x20, 0x17, 0xb7, 0xc0,
+	0x71, 0xc9, 0x89, 0x79, 0x21, 0x83, 0x2b, 0x4f, 0x11, 0xcb, 0x0a, 0x73, 0x2c, 0x5b, 0xba, 0x82,
+	0x65, 0xe8, 0xc7, 0x50, 0xe3, 0x55, 0x3e, 0x91, 0x03, 0xba, 0xb8, 0x38, 0xaa, 0x8c, 0xff, 0xb9,
+	0x97, 0x52, 0x81, 0xa5, 0xab, 0x54, 0xa0, 0x0e, 0x6b, 0xf1, 0x84, 0xc8, 0xba, 0xdb, 0x81, 0x12,
+	0x76, 0xcc, 0xfe, 0x19, 0x99, 0xf2, 0x20, 0x55, 0xf7, 0x97, 0xc3, 0x39, 0x20, 0x38, 0x8b, 0xd8,
+	0x31, 0x59, 0x24, 0x57, 0x20, 0xcf, 0xb8, 0x44, 0xe0, 0xd8, 0xa3, 0xd6, 0x85, 0x55, 0x3e, 0x5d,
+	0x38, 0x5f, 0x58, 0xca, 0x6d, 0x28, 0x4b, 0x91, 0x7e, 0x21, 0xcf, 0xc8, 0x2c, 0x09, 0x99, 0x9e,
+	0xf6, 0x3e, 0xac, 0xea, 0xe4, 0xdc, 0x3e, 0x4b, 0xe0, 0xa4, 0x01, 0xc5, 0x33, 0x12, 0x29, 0xc3,
+	0xa5, 0x33, 0xc2, 0x2a, 0xf0, 0x02, 0x36, 0x85, 0x13, 0x47, 0xc4, 0x3d, 0x37, 0x07, 0xa4, 0x3b,
+	0x18, 0xd8, 0x13, 0x8b, 0xce, 0x43, 0xd7, 0x16, 0x54, 0xbc, 0x89, 0x43, 0x5c, 0x56, 0x18, 0xdc,
+	0xf6, 0xb2, 0x1e, 0x12, 0x78, 0x31, 0x10, 0x97, 0x9a, 0x27, 0xe6, 0x00, 0x53, 0xd2, 0xf7, 0xb0,
+	0x3f, 0x5f, 0xea, 0x11, 0xf2, 0x11, 0xb6, 0xb4, 0xbf, 0x2b, 0x50, 0x8f, 0x2b, 0xbd, 0x14, 0xb2,
+	0x62, 0xda, 0xf3, 0x97, 0xd0, 0x5e, 0x48, 0xd3, 0x9e, 0xc8, 0xfb, 0xd2, 0x55, 0xf2, 0xfe, 0x02,
+	0x54, 0x96, 0xa3, 0xb8, 0xed, 0x41, 0xe3, 0x8a, 0xcd, 0x5d, 0x65, 0xee, 0xdc, 0xcd, 0x25, 0xe7,
+	0xee, 0xef, 0x15, 0xd8, 0x4c, 0x15, 0x2d, 0x61, 0xf0, 0x10, 0x56, 0x3c, 0xf1, 0xaa, 0x8f, 0xe5,
+	0x3b, 0x09, 0x87, 0xf5, 0x00, 0x0e, 0x89, 0x3c, 0x2e, 0x7b, 0x71, 0x59, 0x97, 0xee, 0xed, 0x9f,
+	0xc0, 0xe6, 0x63, 0x32, 0x22, 0x59, 0xc0, 0x78, 0x1f, 0x50, 0xc2, 0x94, 0x10, 0x5a, 0x2b, 0x71,
+	0x9d, 0x3d, 0x43, 0xfb, 0x87, 0x02, 0xb7, 0xd3, 0x60, 0x16, 0x87, 0xe8, 0x95, 0x64, 0x66, 0xb5,
+	0x1d, 0xd9, 0x5e, 0xf2, 0x73, 0xda, 0x4b, 0xe1, 0x2a, 0x8d, 0xef, 0x10, 0xb6, 0x67, 0x53, 0x12,
+	0xd4, 0xe7, 0xdb, 0x04, 0xe3, 0x14, 0x6e, 0x8b, 0x02, 0xfd, 0xff, 0xc5, 0x22, 0x2c, 0xee, 0x5c,
+	0xb4, 0xb8, 0x4b, 0xb0, 0x74, 0x30, 0x76, 0xe8, 0xb4, 0xfd, 0x00, 0x6a, 0xd1, 0x7f, 0x6c, 0xa8,
+	0x09, 0xe8, 0xe0, 0xc5, 0xe1, 0x33, 0xfd, 0xb8, 0xff, 0xe4, 0x99, 0xfe, 0xb4, 0x7b, 0xdc, 0xff,
+	0xf9, 0xd1, 0xb3, 0x4f, 0x57, 0xbe, 0x83, 0x1a, 0x70, 0x3d, 0x4e, 0xff, 0xa2, 0x77, 0xb8, 0xa2,
+	0xec, 0xff, 0x7b, 0x0d, 0x4a, 0x6c, 0xbc, 0x76, 0x1d, 0x07, 0x7d, 0x0a, 0xc5, 0x23, 0x73, 0x68,
+	0xf5, 0x2c, 0xb4, 0x16, 0xb9, 0xde, 0x06, 0x6b, 0x06, 0xb5, 0x91, 0xa0, 0x0a, 0xe8, 0x6a, 0xeb,
+	0xbf, 0xfd, 0xd7, 0x7f, 0xff, 0x90, 0xbb, 0xae, 0xd5, 0x3a, 0xe7, 0x1f, 0x74, 0x3c, 0xe2, 0x79,
+	0x6c, 0x93, 0xf1, 0x91, 0xd2, 0x46, 0x3f, 0x83, 0x12, 0x93, 0xf7, 0x6c, 0x42, 0x51, 0x3d, 0xfc,
+	0x7b, 0xc9, 0xac, 0x56, 0x13, 0x67, 0x6d, 0x8b, 0xcb, 0x68, 0xb6, 0xd7, 0xa2, 0x32, 0x3a, 0x7c,
+	0x40, 0x5a, 0x14, 0xbd, 0x04, 0x08, 0xff, 0xc3, 0x23, 0x35, 0xf8, 0x76, 0x66, 0x77, 0xa0, 0x6e,
+	0xa6, 0xbe, 0x93, 0x86, 0xae, 0x71, 0x25, 0x75, 0xad, 0xc2, 0x94, 0xb0, 0x9e, 0xc2, 0xad, 0xfc,
+	0x0c, 0x20, 0xfc, 0xc7, 0x1f, 0x11, 0x3e, 0xb3, 0x06, 0x98, 0x31, 0x5a, 0x3a, 0xbe, 0x5f, 0x0b,
+	0xe4, 0x75, 0xc6, 0x84, 0x89, 0x7c, 0x08, 0x20, 0x0a, 0x8c, 0x8b, 0x5c, 0xe4, 0xbb, 0x34, 0xab,
+	0x1d, 0x13, 0x83, 0xc6, 0x50, 0x8b, 0x8e, 0x20, 0xb4, 0x95, 0xf0, 0x2c, 0x86, 0x29, 0xf5, 0x46,
+	0xc6, 0x5b, 0xe9, 0xf9, 0x36, 0x57, 0xa1, 0x6a, 0x8d, 0xa8, 0x0a, 0xb6, 0x7c, 0xda, 0x65, 0x73,
+	0x87, 0x99, 0xfc, 0x05, 0x54, 0x23, 0xd3, 0x69, 0xc6, 0xe6, 0x50, 0x7b, 0xca, 0x0c, 0xd3, 0x6e,
+	0x70, 0xf1, 0xeb, 0x28, 0x5d, 0x3c, 0x32, 0xa0, 0x16, 0x1d, 0x5b, 0x11, 0x57, 0x52, 0xa6, 0xd9,
+	0x4c, 0x78, 0xee, 0x72, 0xe1, 0xdb, 0xed, 0x9b, 0xa9, 0xc2, 0x3b, 0xaf, 0x45, 0x75, 0xbc, 0x41,
+	0x9f, 0x41, 0x5d, 0xae, 0x5c, 0x82, 0x99, 0xb3, 0x20, 0xf0, 0xb7, 0xb8, 0xe4, 0x0d, 0x6d, 0x2d,
+	0x26, 0x59, 0x6e, 0xac, 0x58, 0x50, 0x5e, 0x41, 0x35, 0xb2, 0xc5, 0x41, 0x9b, 0x11, 0xbb, 0x93,
+	0xbb, 0x9d, 0x2c, 0xb3, 0xb5, 0xcd, 0x50, 0xf8, 0x6b, 0x79, 0xfd, 0x7c, 0x13, 0xd5, 0xf1, 0xd2,
+	0xaf, 0xdf, 0xa7, 0x53, 0xb6, 0xb8, 0x41, 0xcd, 0xc4, 0x22, 0xc6, 0x97, 0xbf, 0x96, 0xa0, 0xf3,
+	0x45, 0x8c, 0xb6, 0xc9, 0xb5, 0x34, 0xd0, 0x6a, 0xcc, 0x05, 0xc2, 0x39, 0xee, 0x2b, 0x68, 0x04,
+	0xf5, 0xf8, 0x5e, 0x08, 0xdd, 0x4c, 0x88, 0x49, 0x2c, 0x8c, 0x32, 0xd4, 0x68, 0x5c, 0xcd, 0x16,
+	0x52, 0xd3, 0x9c, 0x09, 0xb4, 0xbd, 0x10, 0x0b, 0x1b, 0xf2, 0x74, 0x9a, 0x95, 0x81, 0x70, 0x96,
+	0xc5, 0x37, 0x3b, 0x3e, 0x82, 0x34, 0x14, 0xf7, 0x83, 0x49, 0x63, 0x41, 0x22, 0x50, 0x09, 0x16,
+	0x47, 0x68, 0x23, 0x26, 0x24, 0x96, 0x84, 0x4c, 0xf9, 0x77, 0xb8, 0xfc, 0x9b, 0xda, 0x46, 0xaa,
+	0x03, 0xbe, 0x9a, 0x73, 0xff, 0xda, 0x97, 0xb8, 0xbc, 0xdc, 0x49, 0x54, 0x57, 0xea, 0xdc, 0x54,
+	0xb3, 0x06, 0x75, 0x1c, 0x67, 0xb2, 0xc1, 0xef, 0xfa, 0x53, 0x9e, 0xe9, 0xfd, 0x95, 0xb8, 0x1a,
+	0x1e, 0x25, 0xe6, 0xf9, 0x77, 0x63, 0x45, 0x97, 0x7e, 0x29, 0x51, 0xef, 0xcc, 0x67, 0x92, 0x15,
+	0x2a, 0xfb, 0x2b, 0x4a, 0x35, 0x01, 0xfd, 0x1a, 0xd6, 0xd2, 0x2e, 0x04, 0x11, 0xbf, 0xe7, 0xdc,
+	0x17, 0x66, 0x90, 0xff, 0x01, 0xd7, 0x75, 0xaf, 0xfd, 0x5e, 0x9a, 0xae, 0xce, 0xeb, 0xd9, 0xd9,
+	0xf7, 0x06, 0xfd, 0x4d, 0x01, 0x35, 0xfb, 0x12, 0x81, 0xda, 0x73, 0xe3, 0x7f, 0xa5, 0x4e, 0xf8,
+	0x80, 0x1b, 0xf7, 0xa1, 0xb6, 0x7f, 0x69, 0xe3, 0x62, 0x6d, 0xf2, 0x2f, 0x0a, 0x6c, 0x64, 0xde,
+	0x19, 0xd0, 0x7b, 0x73, 0x72, 0x11, 0xbf, 0x57, 0x2c, 0x68, 0xa8, 0x1f, 0x71, 0x2b, 0xbf, 0x8f,
+	0xde, 0xc2, 0x4a, 0xf4, 0x67, 0x05, 0xd4, 0xec, 0x4b, 0x48, 0x24, 0x96, 0x0b, 0x6f, 0x2a, 0x33,
+	0x99, 0x7d, 0xc4, 0xcd, 0x7a, 0xd0, 0xfe, 0xf8, 0xea, 0x66, 0x85, 0x7d, 0xda, 0x82, 0xe5, 0xc4,
+	0x96, 0x0d, 0xdd, 0x8a, 0x07, 0x63, 0x66, 0xa5, 0xaa, 0x6e, 0x67, 0x33, 0xc8, 0x88, 0xb5, 0xb8,
+	0x69, 0x08, 0xad, 0x30, 0xd3, 0x30, 0x63, 0xd8, 0x95, 0xdb, 0xb8, 0xdf, 0x29, 0xd0, 0x48, 0xdd,
+	0xa6, 0xa0, 0x77, 0x62, 0x52, 0xb3, 0xd6, 0x70, 0xea, 0xdd, 0x45, 0x6c, 0xd2, 0x84, 0x9b, 0xdc,
+	0x84, 0x16, 0x6a, 0x32, 0x13, 0xbe, 0x12, 0x6c, 0xbb, 0x91, 0xd5, 0xcb, 0x37, 0x0a, 0x34, 0x52,
+	0x77, 0x2a, 0x11, 0x43, 0xe6, 0xed, 0x5c, 0xd4, 0xcc, 0x0d, 0x8f, 0xf6, 0x03, 0xae, 0xfa, 0xbe,
+	0x76, 0x2f, 0x5d, 0x75, 0xe7, 0x75, 0x64, 0x57, 0xc3, 0xa6, 0x0f, 0x53, 0xc2, 0xe0, 0x8c, 0x61,
+	0x39, 0xb1, 0xa4, 0x89, 0xe4, 0x22, 0x7d, 0x7d, 0xa3, 0xa2, 0xf0, 0x86, 0xe4, 0xbf, 0xf3, 0x6f,
+	0x42, 0x68, 0xd9, 0x6f, 0xaf, 0x32, 0xf8, 0xf7, 0x95, 0x57, 0x45, 0x7e, 0x07, 0xff, 0xde, 0xff,
+	0x06, 0x00, 0x87, 0x20, 0xdc, 0x9c, 0x12, 0x1b, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// UserAppClient is the client API for UserApp service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type UserAppClient interface {
+	SignIn(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	SignOut(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
+	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteUser(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// CreateAPIKey creates a key to call the service as the user without a session, it can only be called with a session
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	RestoreAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*Empty, error)
+	ExportMyData(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (UserApp_ExportMyDataClient, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (UserApp_ExportUserDataClient, error)
+	EraseMyAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ErasureReceipt, error)
+	EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*ErasureReceipt, error)
+	// CreateServiceAccount creates a principal for a machine client, it authenticates with its API keys, its client
+	// certificate or its client credentials but never with a password
+	CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*ServiceAccount, error)
+	ListServiceAccounts(ctx context.Context, in *ListServiceAccountsRequest, opts ...grpc.CallOption) (*ListServiceAccountsResponse, error)
+	DeleteServiceAccount(ctx context.Context, in *DeleteServiceAccountRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateServiceAccountAPIKey(ctx context.Context, in *CreateServiceAccountAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	ListServiceAccountAPIKeys(ctx context.Context, in *ListServiceAccountAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	RevokeServiceAccountAPIKey(ctx context.Context, in *RevokeServiceAccountAPIKeyRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+	WatchUserEvents(ctx context.Context, in *WatchUserEventsRequest, opts ...grpc.CallOption) (UserApp_WatchUserEventsClient, error)
+}
+
+type userAppClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewUserAppClient(cc *grpc.ClientConn) UserAppClient {
+	return &userAppClient{cc}
+}
+
+func (c *userAppClient) SignIn(ctx context.Context, in *AuthRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/SignIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) SignOut(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/SignOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
+	out := new(CreateUserResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/UpdateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) DeleteUser(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/DeleteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/CreateAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ListAPIKeys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ListAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/RevokeAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) RestoreAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/RestoreAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/RestoreUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ExportMyData(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (UserApp_ExportMyDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserApp_serviceDesc.Streams[0], "/message.UserApp/ExportMyData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userAppExportMyDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserApp_ExportMyDataClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type userAppExportMyDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *userAppExportMyDataClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userAppClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (UserApp_ExportUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserApp_serviceDesc.Streams[1], "/message.UserApp/ExportUserData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userAppExportUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserApp_ExportUserDataClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type userAppExportUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *userAppExportUserDataClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userAppClient) EraseMyAccount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ErasureReceipt, error) {
+	out := new(ErasureReceipt)
+	err := c.cc.Invoke(ctx, "/message.UserApp/EraseMyAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) EraseUser(ctx context.Context, in *EraseUserRequest, opts ...grpc.CallOption) (*ErasureReceipt, error) {
+	out := new(ErasureReceipt)
+	err := c.cc.Invoke(ctx, "/message.UserApp/EraseUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) CreateServiceAccount(ctx context.Context, in *CreateServiceAccountRequest, opts ...grpc.CallOption) (*ServiceAccount, error) {
+	out := new(ServiceAccount)
+	err := c.cc.Invoke(ctx, "/message.UserApp/CreateServiceAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ListServiceAccounts(ctx context.Context, in *ListServiceAccountsRequest, opts ...grpc.CallOption) (*ListServiceAccountsResponse, error) {
+	out := new(ListServiceAccountsResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ListServiceAccounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) DeleteServiceAccount(ctx context.Context, in *DeleteServiceAccountRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/DeleteServiceAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) CreateServiceAccountAPIKey(ctx context.Context, in *CreateServiceAccountAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/CreateServiceAccountAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ListServiceAccountAPIKeys(ctx context.Context, in *ListServiceAccountAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ListServiceAccountAPIKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) RevokeServiceAccountAPIKey(ctx context.Context, in *RevokeServiceAccountAPIKeyRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/message.UserApp/RevokeServiceAccountAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ListAuditEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ListWebhookDeliveries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, "/message.UserApp/ReplayWebhookDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userAppClient) WatchUserEvents(ctx context.Context, in *WatchUserEventsRequest, opts ...grpc.CallOption) (UserApp_WatchUserEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_UserApp_serviceDesc.Streams[2], "/message.UserApp/WatchUserEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userAppWatchUserEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type UserApp_WatchUserEventsClient interface {
+	Recv() (*UserEvent, error)
+	grpc.ClientStream
+}
+
+type userAppWatchUserEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *userAppWatchUserEventsClient) Recv() (*UserEvent, error) {
+	m := new(UserEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UserAppServer is the server API for UserApp service.
+type UserAppServer interface {
+	SignIn(context.Context, *AuthRequest) (*AuthResponse, error)
+	SignOut(context.Context, *Empty) (*Empty, error)
+	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
+	UpdateUser(context.Context, *UpdateUserRequest) (*Empty, error)
+	DeleteUser(context.Context, *Empty) (*Empty, error)
+	// CreateAPIKey creates a key to call the service as the user without a session, it can only be called with a session
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	ListAPIKeys(context.Context, *Empty) (*ListAPIKeysResponse, error)
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*Empty, error)
+	RestoreAccount(context.Context, *Empty) (*Empty, error)
+	RestoreUser(context.Context, *RestoreUserRequest) (*Empty, error)
+	ExportMyData(*ExportRequest, UserApp_ExportMyDataServer) error
+	ExportUserData(*ExportUserDataRequest, UserApp_ExportUserDataServer) error
+	EraseMyAccount(context.Context, *Empty) (*ErasureReceipt, error)
+	EraseUser(context.Context, *EraseUserRequest) (*ErasureReceipt, error)
+	// CreateServiceAccount creates a principal for a machine client, it authenticates with its API keys, its client
+	// certificate or its client credentials but never with a password
+	CreateServiceAccount(context.Context, *CreateServiceAccountRequest) (*ServiceAccount, error)
+	ListServiceAccounts(context.Context, *ListServiceAccountsRequest) (*ListServiceAccountsResponse, error)
+	DeleteServiceAccount(context.Context, *DeleteServiceAccountRequest) (*Empty, error)
+	CreateServiceAccountAPIKey(context.Context, *CreateServiceAccountAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	ListServiceAccountAPIKeys(context.Context, *ListServiceAccountAPIKeysRequest) (*ListAPIKeysResponse, error)
+	RevokeServiceAccountAPIKey(context.Context, *RevokeServiceAccountAPIKeyRequest) (*Empty, error)
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error)
+	WatchUserEvents(*WatchUserEventsRequest, UserApp_WatchUserEventsServer) error
+}
+
+// UnimplementedUserAppServer can be embedded to have forward compatible implementations.
+type UnimplementedUserAppServer struct {
+}
+
+func (*UnimplementedUserAppServer) SignIn(ctx context.Context, req *AuthRequest) (*AuthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignIn not implemented")
+}
+func (*UnimplementedUserAppServer) SignOut(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignOut not implemented")
+}
+func (*UnimplementedUserAppServer) CreateUser(ctx context.Context, req *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (*UnimplementedUserAppServer) UpdateUser(ctx context.Context, req *UpdateUserRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
+}
+func (*UnimplementedUserAppServer) DeleteUser(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (*UnimplementedUserAppServer) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (*UnimplementedUserAppServer) ListAPIKeys(ctx context.Context, req *Empty) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (*UnimplementedUserAppServer) RevokeAPIKey(ctx context.Context, req *RevokeAPIKeyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (*UnimplementedUserAppServer) RestoreAccount(ctx context.Context, req *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAccount not implemented")
+}
+func (*UnimplementedUserAppServer) RestoreUser(ctx context.Context, req *RestoreUserRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (*UnimplementedUserAppServer) ExportMyData(req *ExportRequest, srv UserApp_ExportMyDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (*UnimplementedUserAppServer) ExportUserData(req *ExportUserDataRequest, srv UserApp_ExportUserDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (*UnimplementedUserAppServer) EraseMyAccount(ctx context.Context, req *Empty) (*ErasureReceipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseMyAccount not implemented")
+}
+func (*UnimplementedUserAppServer) EraseUser(ctx context.Context, req *EraseUserRequest) (*ErasureReceipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseUser not implemented")
+}
+func (*UnimplementedUserAppServer) CreateServiceAccount(ctx context.Context, req *CreateServiceAccountRequest) (*ServiceAccount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccount not implemented")
+}
+func (*UnimplementedUserAppServer) ListServiceAccounts(ctx context.Context, req *ListServiceAccountsRequest) (*ListServiceAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceAccounts not implemented")
+}
+func (*UnimplementedUserAppServer) DeleteServiceAccount(ctx context.Context, req *DeleteServiceAccountRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteServiceAccount not implemented")
+}
+func (*UnimplementedUserAppServer) CreateServiceAccountAPIKey(ctx context.Context, req *CreateServiceAccountAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateServiceAccountAPIKey not implemented")
+}
+func (*UnimplementedUserAppServer) ListServiceAccountAPIKeys(ctx context.Context, req *ListServiceAccountAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceAccountAPIKeys not implemented")
+}
+func (*UnimplementedUserAppServer) RevokeServiceAccountAPIKey(ctx context.Context, req *RevokeServiceAccountAPIKeyRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeServiceAccountAPIKey not implemented")
+}
+func (*UnimplementedUserAppServer) ListAuditEvents(ctx context.Context, req *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (*UnimplementedUserAppServer) ListWebhookDeliveries(ctx context.Context, req *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (*UnimplementedUserAppServer) ReplayWebhookDelivery(ctx context.Context, req *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (*UnimplementedUserAppServer) WatchUserEvents(req *WatchUserEventsRequest, srv UserApp_WatchUserEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUserEvents not implemented")
+}
+
+func RegisterUserAppServer(s *grpc.Server, srv UserAppServer) {
+	s.RegisterService(&_UserApp_serviceDesc, srv)
+}
+
+func _UserApp_SignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).SignIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/SignIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).SignIn(ctx, req.(*AuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_SignOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).SignOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/SignOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).SignOut(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).CreateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/CreateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).CreateUser(ctx, req.(*CreateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_UpdateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).UpdateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/UpdateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).UpdateUser(ctx, req.(*UpdateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/DeleteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).DeleteUser(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/CreateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ListAPIKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ListAPIKeys(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/RevokeAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_RestoreAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).RestoreAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/RestoreAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).RestoreAccount(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/RestoreUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ExportMyData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserAppServer).ExportMyData(m, &userAppExportMyDataServer{stream})
+}
+
+type UserApp_ExportMyDataServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type userAppExportMyDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *userAppExportMyDataServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserApp_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserAppServer).ExportUserData(m, &userAppExportUserDataServer{stream})
+}
+
+type UserApp_ExportUserDataServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type userAppExportUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *userAppExportUserDataServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _UserApp_EraseMyAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).EraseMyAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/EraseMyAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).EraseMyAccount(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_EraseUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).EraseUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/EraseUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).EraseUser(ctx, req.(*EraseUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_CreateServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).CreateServiceAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/CreateServiceAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).CreateServiceAccount(ctx, req.(*CreateServiceAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ListServiceAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ListServiceAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ListServiceAccounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ListServiceAccounts(ctx, req.(*ListServiceAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_DeleteServiceAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteServiceAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).DeleteServiceAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/DeleteServiceAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).DeleteServiceAccount(ctx, req.(*DeleteServiceAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_CreateServiceAccountAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateServiceAccountAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).CreateServiceAccountAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/CreateServiceAccountAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).CreateServiceAccountAPIKey(ctx, req.(*CreateServiceAccountAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ListServiceAccountAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServiceAccountAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ListServiceAccountAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ListServiceAccountAPIKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ListServiceAccountAPIKeys(ctx, req.(*ListServiceAccountAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_RevokeServiceAccountAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeServiceAccountAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).RevokeServiceAccountAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/RevokeServiceAccountAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).RevokeServiceAccountAPIKey(ctx, req.(*RevokeServiceAccountAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ListAuditEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ListWebhookDeliveries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserAppServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.UserApp/ReplayWebhookDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserAppServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserApp_WatchUserEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserAppServer).WatchUserEvents(m, &userAppWatchUserEventsServer{stream})
+}
+
+type UserApp_WatchUserEventsServer interface {
+	Send(*UserEvent) error
+	grpc.ServerStream
+}
+
+type userAppWatchUserEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *userAppWatchUserEventsServer) Send(m *UserEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _UserApp_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "message.UserApp",
 	HandlerType: (*UserAppServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -551,7 +3014,87 @@ var _UserApp_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _UserApp_DeleteUser_Handler,
 		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _UserApp_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _UserApp_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _UserApp_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "RestoreAccount",
+			Handler:    _UserApp_RestoreAccount_Handler,
+		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _UserApp_RestoreUser_Handler,
+		},
+		{
+			MethodName: "EraseMyAccount",
+			Handler:    _UserApp_EraseMyAccount_Handler,
+		},
+		{
+			MethodName: "EraseUser",
+			Handler:    _UserApp_EraseUser_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccount",
+			Handler:    _UserApp_CreateServiceAccount_Handler,
+		},
+		{
+			MethodName: "ListServiceAccounts",
+			Handler:    _UserApp_ListServiceAccounts_Handler,
+		},
+		{
+			MethodName: "DeleteServiceAccount",
+			Handler:    _UserApp_DeleteServiceAccount_Handler,
+		},
+		{
+			MethodName: "CreateServiceAccountAPIKey",
+			Handler:    _UserApp_CreateServiceAccountAPIKey_Handler,
+		},
+		{
+			MethodName: "ListServiceAccountAPIKeys",
+			Handler:    _UserApp_ListServiceAccountAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeServiceAccountAPIKey",
+			Handler:    _UserApp_RevokeServiceAccountAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _UserApp_ListAuditEvents_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _UserApp_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _UserApp_ReplayWebhookDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMyData",
+			Handler:       _UserApp_ExportMyData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _UserApp_ExportUserData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchUserEvents",
+			Handler:       _UserApp_WatchUserEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "message/message.proto",
 }
@@ -0,0 +1,5 @@
+DROP INDEX users@users_email_normalized_deleted_at_key CASCADE;
+DROP INDEX users@users_username_normalized_deleted_at_key CASCADE;
+
+ALTER TABLE users DROP COLUMN email_normalized;
+ALTER TABLE users DROP COLUMN username_normalized;
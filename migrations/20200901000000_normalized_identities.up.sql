@@ -0,0 +1,10 @@
+-- username and email are stored in their canonical form (see pkg/normalize), the computed columns hold the lower
+-- cased form that the uniqueness is enforced on. They have no length limit, lower casing can make a value longer.
+-- Creating the unique indexes fails when case-insensitive duplicates already exist: run `user.app users normalize`, to
+-- store the existing rows in their canonical form, and `user.app users collisions`, to list the duplicates and resolve
+-- them, before migrating.
+ALTER TABLE users ADD COLUMN username_normalized STRING AS (lower(username)) STORED;
+ALTER TABLE users ADD COLUMN email_normalized STRING AS (lower(email)) STORED;
+
+CREATE UNIQUE INDEX users_username_normalized_deleted_at_key ON users (username_normalized, deleted_at);
+CREATE UNIQUE INDEX users_email_normalized_deleted_at_key ON users (email_normalized, deleted_at);
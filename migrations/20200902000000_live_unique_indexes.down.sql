@@ -0,0 +1,24 @@
+-- The unique constraints on username and email cannot be restored once a name has been registered again after a
+-- deletion, the migration stops before dropping anything. Rename or purge the deleted duplicates first.
+SELECT crdb_internal.force_error(
+    '23505',
+    'cannot restore UNIQUE (username) and UNIQUE (email): some usernames or emails are shared by several users, ' ||
+    'rename or purge the deleted ones first'
+)
+FROM (
+    SELECT username FROM users GROUP BY username HAVING count(*) > 1
+    UNION ALL
+    SELECT email FROM users GROUP BY email HAVING count(*) > 1
+) AS duplicates
+LIMIT 1;
+
+DROP INDEX users@users_deleted_at_idx;
+DROP INDEX users@users_live_email_normalized_key CASCADE;
+DROP INDEX users@users_live_username_normalized_key CASCADE;
+
+CREATE UNIQUE INDEX users_username_normalized_deleted_at_key ON users (username_normalized, deleted_at);
+CREATE UNIQUE INDEX users_email_normalized_deleted_at_key ON users (email_normalized, deleted_at);
+CREATE UNIQUE INDEX users_username_deleted_at_key ON users (username, deleted_at);
+CREATE UNIQUE INDEX users_email_deleted_at_key ON users (email, deleted_at);
+CREATE UNIQUE INDEX users_username_key ON users (username);
+CREATE UNIQUE INDEX users_email_key ON users (email);
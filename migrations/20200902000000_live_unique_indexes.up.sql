@@ -0,0 +1,15 @@
+-- Usernames and emails only have to be unique among the users that have not been deleted, the partial indexes let
+-- a soft deleted username or email be registered again. The reuse cooldown is enforced by the application.
+-- The partial indexes require CockroachDB 20.2 or later. The down migration fails once a name has been reused.
+DROP INDEX users@users_username_key CASCADE;
+DROP INDEX users@users_email_key CASCADE;
+DROP INDEX users@users_username_deleted_at_key CASCADE;
+DROP INDEX users@users_email_deleted_at_key CASCADE;
+DROP INDEX users@users_username_normalized_deleted_at_key CASCADE;
+DROP INDEX users@users_email_normalized_deleted_at_key CASCADE;
+
+CREATE UNIQUE INDEX users_live_username_normalized_key ON users (username_normalized) WHERE deleted_at = '1970-01-01';
+CREATE UNIQUE INDEX users_live_email_normalized_key ON users (email_normalized) WHERE deleted_at = '1970-01-01';
+
+-- used by the reuse cooldown check and the retention job
+CREATE INDEX users_deleted_at_idx ON users (deleted_at);
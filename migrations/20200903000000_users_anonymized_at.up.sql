@@ -0,0 +1,2 @@
+-- anonymized_at is set when the restore grace period of a deleted user is over and its personal data was removed
+ALTER TABLE users ADD COLUMN anonymized_at TIMESTAMPTZ NULL;
@@ -0,0 +1 @@
+DROP TABLE erasure_receipts;
@@ -0,0 +1,10 @@
+-- erasure_receipts records the irreversible erasure of a user's personal data. It has no foreign key to users on
+-- purpose, the receipt has to outlive the purged user row.
+CREATE TABLE erasure_receipts
+(
+    id           UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL UNIQUE,
+    trigger      STRING(20)  NOT NULL,
+    requested_by UUID        NULL,
+    erased_at    TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,18 @@
+-- audit_events is an append only log of the mutations made through the API. Every row is chained to the previous one,
+-- hash = sha256(prev_hash || event), so that editing or deleting a row breaks the chain, see `user.app audit verify`.
+CREATE TABLE audit_events
+(
+    id             UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    seq            INT8        NOT NULL UNIQUE,
+    actor_id       UUID        NULL,
+    target_user_id UUID        NULL,
+    action         STRING(50)  NOT NULL,
+    changed_fields STRING[]    NOT NULL DEFAULT ARRAY[],
+    request_id     STRING(100) NOT NULL DEFAULT '',
+    client_ip      STRING(45)  NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL,
+    prev_hash      BYTES       NOT NULL,
+    hash           BYTES       NOT NULL,
+    INDEX audit_events_target_user_id_idx (target_user_id, seq),
+    INDEX audit_events_actor_id_idx (actor_id, seq)
+);
@@ -0,0 +1,29 @@
+-- outbox holds the user events written in the same transaction as the mutation, they are delivered to the webhook
+-- endpoints by the dispatcher running inside `server`. seq has no default, the writers number the events after the
+-- last one so that the cursors of the readers never skip an event committed later.
+CREATE TABLE outbox
+(
+    id          UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    seq         INT8        NOT NULL UNIQUE,
+    event_type  STRING(50)  NOT NULL,
+    user_id     UUID        NOT NULL,
+    payload     JSONB       NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL
+);
+
+-- webhook_deliveries is one delivery of an outbox event to a configured endpoint, the endpoint is referenced by its
+-- name so that its url and secret can be changed without rewriting the pending deliveries
+CREATE TABLE webhook_deliveries
+(
+    id              UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    outbox_id       UUID        NOT NULL REFERENCES outbox (id) ON DELETE CASCADE,
+    endpoint        STRING(100) NOT NULL,
+    status          STRING(20)  NOT NULL DEFAULT 'pending',
+    attempts        INT4        NOT NULL DEFAULT 0,
+    last_error      STRING      NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    delivered_at    TIMESTAMPTZ NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    UNIQUE (outbox_id, endpoint),
+    INDEX webhook_deliveries_status_next_attempt_at_idx (status, next_attempt_at)
+);
@@ -0,0 +1,9 @@
+-- rate_limit_buckets are the token buckets of the rate limiter when they are shared by the nodes of a cluster, the id
+-- is the method and the key of the client. The buckets that would be full again are pruned.
+CREATE TABLE rate_limit_buckets
+(
+    id         STRING(300) PRIMARY KEY,
+    tokens     FLOAT8      NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    INDEX rate_limit_buckets_updated_at_idx (updated_at)
+);
@@ -0,0 +1,16 @@
+-- api_keys are the personal access tokens of the users. Only the sha256 of a key is stored, its prefix is kept in
+-- clear to find it and to tell the keys apart. Revoked keys are kept with their last use until the user is purged.
+CREATE TABLE api_keys
+(
+    id           UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         STRING(100) NOT NULL,
+    prefix       STRING(20)  NOT NULL UNIQUE,
+    hash         BYTES       NOT NULL,
+    scopes       STRING[]    NOT NULL,
+    expires_at   TIMESTAMPTZ NULL,
+    last_used_at TIMESTAMPTZ NULL,
+    revoked_at   TIMESTAMPTZ NULL,
+    created_at   TIMESTAMPTZ NOT NULL,
+    INDEX api_keys_user_id_idx (user_id)
+);
@@ -0,0 +1,4 @@
+DROP INDEX users@users_live_certificate_san_key CASCADE;
+
+ALTER TABLE users DROP COLUMN certificate_san;
+ALTER TABLE users DROP COLUMN principal_type;
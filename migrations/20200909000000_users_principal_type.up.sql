@@ -0,0 +1,7 @@
+-- principal_type tells the humans from the service accounts, the service accounts cannot sign in with a password.
+-- certificate_san is the SAN of the client certificates a service account authenticates with, it is unique among the
+-- users that have not been deleted like the usernames.
+ALTER TABLE users ADD COLUMN principal_type STRING(10) NOT NULL DEFAULT 'human' CHECK (principal_type IN ('human', 'service'));
+ALTER TABLE users ADD COLUMN certificate_san STRING(255) NULL;
+
+CREATE UNIQUE INDEX users_live_certificate_san_key ON users (certificate_san) WHERE deleted_at = '1970-01-01';
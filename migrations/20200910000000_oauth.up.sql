@@ -0,0 +1,29 @@
+-- oauth_clients are the OAuth2 clients registered with `user.app clients create`, the id is the client_id. Only the
+-- sha256 of a secret is stored. The client_credentials tokens are issued to the service account of the client.
+CREATE TABLE oauth_clients
+(
+    id                 STRING(40)  PRIMARY KEY,
+    name               STRING(100) NOT NULL,
+    secret_hash        BYTES       NOT NULL,
+    service_account_id UUID        NULL REFERENCES users (id) ON DELETE CASCADE,
+    grant_types        STRING[]    NOT NULL,
+    scopes             STRING[]    NOT NULL,
+    created_at         TIMESTAMPTZ NOT NULL,
+    revoked_at         TIMESTAMPTZ NULL
+);
+
+-- oauth_refresh_tokens are the refresh tokens issued to the clients, stored hashed. A refresh token is bound to the
+-- session of its access token and is rotated on every use.
+CREATE TABLE oauth_refresh_tokens
+(
+    id         UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    token_hash BYTES       NOT NULL UNIQUE,
+    client_id  STRING(40)  NOT NULL REFERENCES oauth_clients (id) ON DELETE CASCADE,
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    session_id STRING(36)  NOT NULL,
+    scopes     STRING[]    NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    INDEX oauth_refresh_tokens_user_id_idx (user_id)
+);
@@ -0,0 +1,3 @@
+DROP TABLE oauth_authorization_codes;
+DROP TABLE oauth_consents;
+ALTER TABLE oauth_clients DROP COLUMN redirect_uris;
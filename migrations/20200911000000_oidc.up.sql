@@ -0,0 +1,34 @@
+-- redirect_uris are the exact URIs the authorization codes of a client can be sent to
+ALTER TABLE oauth_clients ADD COLUMN redirect_uris STRING[] NOT NULL DEFAULT ARRAY[];
+
+-- oauth_consents are the scopes each user has allowed each client to request, the consent page is skipped when they
+-- cover the requested ones
+CREATE TABLE oauth_consents
+(
+    user_id    UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    client_id  STRING(40)  NOT NULL REFERENCES oauth_clients (id) ON DELETE CASCADE,
+    scopes     STRING[]    NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, client_id)
+);
+
+-- oauth_authorization_codes are the codes of the authorization code grant, stored hashed. A code is used once, the
+-- session of the tokens it was exchanged for is kept to revoke them when it is replayed.
+CREATE TABLE oauth_authorization_codes
+(
+    id             UUID PRIMARY KEY     DEFAULT gen_random_uuid(),
+    code_hash      BYTES       NOT NULL UNIQUE,
+    client_id      STRING(40)  NOT NULL REFERENCES oauth_clients (id) ON DELETE CASCADE,
+    user_id        UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    redirect_uri   STRING      NOT NULL,
+    scopes         STRING[]    NOT NULL,
+    nonce          STRING(255) NULL,
+    code_challenge STRING(128) NOT NULL,
+    auth_time      TIMESTAMPTZ NOT NULL,
+    session_id     STRING(36)  NULL,
+    expires_at     TIMESTAMPTZ NOT NULL,
+    used_at        TIMESTAMPTZ NULL,
+    created_at     TIMESTAMPTZ NOT NULL,
+    INDEX oauth_authorization_codes_expires_at_idx (expires_at)
+);
@@ -12,57 +12,197 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("APIKeys", testAPIKeys)
+	t.Run("AuditEvents", testAuditEvents)
+	t.Run("ErasureReceipts", testErasureReceipts)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodes)
+	t.Run("OauthClients", testOauthClients)
+	t.Run("OauthConsents", testOauthConsents)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokens)
+	t.Run("Outboxes", testOutboxes)
+	t.Run("RateLimitBuckets", testRateLimitBuckets)
 	t.Run("Users", testUsers)
+	t.Run("WebhookDeliveries", testWebhookDeliveries)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysDelete)
+	t.Run("AuditEvents", testAuditEventsDelete)
+	t.Run("ErasureReceipts", testErasureReceiptsDelete)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesDelete)
+	t.Run("OauthClients", testOauthClientsDelete)
+	t.Run("OauthConsents", testOauthConsentsDelete)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensDelete)
+	t.Run("Outboxes", testOutboxesDelete)
+	t.Run("RateLimitBuckets", testRateLimitBucketsDelete)
 	t.Run("Users", testUsersDelete)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysQueryDeleteAll)
+	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
+	t.Run("ErasureReceipts", testErasureReceiptsQueryDeleteAll)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesQueryDeleteAll)
+	t.Run("OauthClients", testOauthClientsQueryDeleteAll)
+	t.Run("OauthConsents", testOauthConsentsQueryDeleteAll)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensQueryDeleteAll)
+	t.Run("Outboxes", testOutboxesQueryDeleteAll)
+	t.Run("RateLimitBuckets", testRateLimitBucketsQueryDeleteAll)
 	t.Run("Users", testUsersQueryDeleteAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSliceDeleteAll)
+	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
+	t.Run("ErasureReceipts", testErasureReceiptsSliceDeleteAll)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesSliceDeleteAll)
+	t.Run("OauthClients", testOauthClientsSliceDeleteAll)
+	t.Run("OauthConsents", testOauthConsentsSliceDeleteAll)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensSliceDeleteAll)
+	t.Run("Outboxes", testOutboxesSliceDeleteAll)
+	t.Run("RateLimitBuckets", testRateLimitBucketsSliceDeleteAll)
 	t.Run("Users", testUsersSliceDeleteAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysExists)
+	t.Run("AuditEvents", testAuditEventsExists)
+	t.Run("ErasureReceipts", testErasureReceiptsExists)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesExists)
+	t.Run("OauthClients", testOauthClientsExists)
+	t.Run("OauthConsents", testOauthConsentsExists)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensExists)
+	t.Run("Outboxes", testOutboxesExists)
+	t.Run("RateLimitBuckets", testRateLimitBucketsExists)
 	t.Run("Users", testUsersExists)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysFind)
+	t.Run("AuditEvents", testAuditEventsFind)
+	t.Run("ErasureReceipts", testErasureReceiptsFind)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesFind)
+	t.Run("OauthClients", testOauthClientsFind)
+	t.Run("OauthConsents", testOauthConsentsFind)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensFind)
+	t.Run("Outboxes", testOutboxesFind)
+	t.Run("RateLimitBuckets", testRateLimitBucketsFind)
 	t.Run("Users", testUsersFind)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysBind)
+	t.Run("AuditEvents", testAuditEventsBind)
+	t.Run("ErasureReceipts", testErasureReceiptsBind)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesBind)
+	t.Run("OauthClients", testOauthClientsBind)
+	t.Run("OauthConsents", testOauthConsentsBind)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensBind)
+	t.Run("Outboxes", testOutboxesBind)
+	t.Run("RateLimitBuckets", testRateLimitBucketsBind)
 	t.Run("Users", testUsersBind)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysOne)
+	t.Run("AuditEvents", testAuditEventsOne)
+	t.Run("ErasureReceipts", testErasureReceiptsOne)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesOne)
+	t.Run("OauthClients", testOauthClientsOne)
+	t.Run("OauthConsents", testOauthConsentsOne)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensOne)
+	t.Run("Outboxes", testOutboxesOne)
+	t.Run("RateLimitBuckets", testRateLimitBucketsOne)
 	t.Run("Users", testUsersOne)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysAll)
+	t.Run("AuditEvents", testAuditEventsAll)
+	t.Run("ErasureReceipts", testErasureReceiptsAll)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesAll)
+	t.Run("OauthClients", testOauthClientsAll)
+	t.Run("OauthConsents", testOauthConsentsAll)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensAll)
+	t.Run("Outboxes", testOutboxesAll)
+	t.Run("RateLimitBuckets", testRateLimitBucketsAll)
 	t.Run("Users", testUsersAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysCount)
+	t.Run("AuditEvents", testAuditEventsCount)
+	t.Run("ErasureReceipts", testErasureReceiptsCount)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesCount)
+	t.Run("OauthClients", testOauthClientsCount)
+	t.Run("OauthConsents", testOauthConsentsCount)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensCount)
+	t.Run("Outboxes", testOutboxesCount)
+	t.Run("RateLimitBuckets", testRateLimitBucketsCount)
 	t.Run("Users", testUsersCount)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysHooks)
+	t.Run("AuditEvents", testAuditEventsHooks)
+	t.Run("ErasureReceipts", testErasureReceiptsHooks)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesHooks)
+	t.Run("OauthClients", testOauthClientsHooks)
+	t.Run("OauthConsents", testOauthConsentsHooks)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensHooks)
+	t.Run("Outboxes", testOutboxesHooks)
+	t.Run("RateLimitBuckets", testRateLimitBucketsHooks)
 	t.Run("Users", testUsersHooks)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysInsert)
+	t.Run("APIKeys", testAPIKeysInsertWhitelist)
+	t.Run("AuditEvents", testAuditEventsInsert)
+	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
+	t.Run("ErasureReceipts", testErasureReceiptsInsert)
+	t.Run("ErasureReceipts", testErasureReceiptsInsertWhitelist)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesInsert)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesInsertWhitelist)
+	t.Run("OauthClients", testOauthClientsInsert)
+	t.Run("OauthClients", testOauthClientsInsertWhitelist)
+	t.Run("OauthConsents", testOauthConsentsInsert)
+	t.Run("OauthConsents", testOauthConsentsInsertWhitelist)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensInsert)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensInsertWhitelist)
+	t.Run("Outboxes", testOutboxesInsert)
+	t.Run("Outboxes", testOutboxesInsertWhitelist)
+	t.Run("RateLimitBuckets", testRateLimitBucketsInsert)
+	t.Run("RateLimitBuckets", testRateLimitBucketsInsertWhitelist)
 	t.Run("Users", testUsersInsert)
 	t.Run("Users", testUsersInsertWhitelist)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesInsert)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesInsertWhitelist)
 }
 
 // TestToOne tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOne(t *testing.T) {}
+func TestToOne(t *testing.T) {
+	t.Run("APIKeyToUserUsingUser", testAPIKeyToOneUserUsingUser)
+	t.Run("OauthAuthorizationCodeToOauthClientUsingClient", testOauthAuthorizationCodeToOneOauthClientUsingClient)
+	t.Run("OauthAuthorizationCodeToUserUsingUser", testOauthAuthorizationCodeToOneUserUsingUser)
+	t.Run("OauthClientToUserUsingServiceAccount", testOauthClientToOneUserUsingServiceAccount)
+	t.Run("OauthConsentToUserUsingUser", testOauthConsentToOneUserUsingUser)
+	t.Run("OauthConsentToOauthClientUsingClient", testOauthConsentToOneOauthClientUsingClient)
+	t.Run("OauthRefreshTokenToOauthClientUsingClient", testOauthRefreshTokenToOneOauthClientUsingClient)
+	t.Run("OauthRefreshTokenToUserUsingUser", testOauthRefreshTokenToOneUserUsingUser)
+	t.Run("WebhookDeliveryToOutboxUsingOutbox", testWebhookDeliveryToOneOutboxUsingOutbox)
+}
 
 // TestOneToOne tests cannot be run in parallel
 // or deadlocks can occur.
@@ -70,15 +210,37 @@ func TestOneToOne(t *testing.T) {}
 
 // TestToMany tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToMany(t *testing.T) {}
+func TestToMany(t *testing.T) {
+	t.Run("OauthClientToClientOauthAuthorizationCodes", testOauthClientToManyClientOauthAuthorizationCodes)
+	t.Run("OauthClientToClientOauthConsents", testOauthClientToManyClientOauthConsents)
+	t.Run("OauthClientToClientOauthRefreshTokens", testOauthClientToManyClientOauthRefreshTokens)
+	t.Run("OutboxToWebhookDeliveries", testOutboxToManyWebhookDeliveries)
+	t.Run("UserToAPIKeys", testUserToManyAPIKeys)
+	t.Run("UserToOauthAuthorizationCodes", testUserToManyOauthAuthorizationCodes)
+	t.Run("UserToServiceAccountOauthClients", testUserToManyServiceAccountOauthClients)
+	t.Run("UserToOauthConsents", testUserToManyOauthConsents)
+	t.Run("UserToOauthRefreshTokens", testUserToManyOauthRefreshTokens)
+}
 
 // TestToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneSet(t *testing.T) {}
+func TestToOneSet(t *testing.T) {
+	t.Run("APIKeyToUserUsingAPIKeys", testAPIKeyToOneSetOpUserUsingUser)
+	t.Run("OauthAuthorizationCodeToOauthClientUsingClientOauthAuthorizationCodes", testOauthAuthorizationCodeToOneSetOpOauthClientUsingClient)
+	t.Run("OauthAuthorizationCodeToUserUsingOauthAuthorizationCodes", testOauthAuthorizationCodeToOneSetOpUserUsingUser)
+	t.Run("OauthClientToUserUsingServiceAccountOauthClients", testOauthClientToOneSetOpUserUsingServiceAccount)
+	t.Run("OauthConsentToUserUsingOauthConsents", testOauthConsentToOneSetOpUserUsingUser)
+	t.Run("OauthConsentToOauthClientUsingClientOauthConsents", testOauthConsentToOneSetOpOauthClientUsingClient)
+	t.Run("OauthRefreshTokenToOauthClientUsingClientOauthRefreshTokens", testOauthRefreshTokenToOneSetOpOauthClientUsingClient)
+	t.Run("OauthRefreshTokenToUserUsingOauthRefreshTokens", testOauthRefreshTokenToOneSetOpUserUsingUser)
+	t.Run("WebhookDeliveryToOutboxUsingWebhookDeliveries", testWebhookDeliveryToOneSetOpOutboxUsingOutbox)
+}
 
 // TestToOneRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToOneRemove(t *testing.T) {}
+func TestToOneRemove(t *testing.T) {
+	t.Run("OauthClientToUserUsingServiceAccountOauthClients", testOauthClientToOneRemoveOpUserUsingServiceAccount)
+}
 
 // TestOneToOneSet tests cannot be run in parallel
 // or deadlocks can occur.
@@ -90,32 +252,96 @@ func TestOneToOneRemove(t *testing.T) {}
 
 // TestToManyAdd tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyAdd(t *testing.T) {}
+func TestToManyAdd(t *testing.T) {
+	t.Run("OauthClientToClientOauthAuthorizationCodes", testOauthClientToManyAddOpClientOauthAuthorizationCodes)
+	t.Run("OauthClientToClientOauthConsents", testOauthClientToManyAddOpClientOauthConsents)
+	t.Run("OauthClientToClientOauthRefreshTokens", testOauthClientToManyAddOpClientOauthRefreshTokens)
+	t.Run("OutboxToWebhookDeliveries", testOutboxToManyAddOpWebhookDeliveries)
+	t.Run("UserToAPIKeys", testUserToManyAddOpAPIKeys)
+	t.Run("UserToOauthAuthorizationCodes", testUserToManyAddOpOauthAuthorizationCodes)
+	t.Run("UserToServiceAccountOauthClients", testUserToManyAddOpServiceAccountOauthClients)
+	t.Run("UserToOauthConsents", testUserToManyAddOpOauthConsents)
+	t.Run("UserToOauthRefreshTokens", testUserToManyAddOpOauthRefreshTokens)
+}
 
 // TestToManySet tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManySet(t *testing.T) {}
+func TestToManySet(t *testing.T) {
+	t.Run("UserToServiceAccountOauthClients", testUserToManySetOpServiceAccountOauthClients)
+}
 
 // TestToManyRemove tests cannot be run in parallel
 // or deadlocks can occur.
-func TestToManyRemove(t *testing.T) {}
+func TestToManyRemove(t *testing.T) {
+	t.Run("UserToServiceAccountOauthClients", testUserToManyRemoveOpServiceAccountOauthClients)
+}
 
 func TestReload(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysReload)
+	t.Run("AuditEvents", testAuditEventsReload)
+	t.Run("ErasureReceipts", testErasureReceiptsReload)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesReload)
+	t.Run("OauthClients", testOauthClientsReload)
+	t.Run("OauthConsents", testOauthConsentsReload)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensReload)
+	t.Run("Outboxes", testOutboxesReload)
+	t.Run("RateLimitBuckets", testRateLimitBucketsReload)
 	t.Run("Users", testUsersReload)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysReloadAll)
+	t.Run("AuditEvents", testAuditEventsReloadAll)
+	t.Run("ErasureReceipts", testErasureReceiptsReloadAll)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesReloadAll)
+	t.Run("OauthClients", testOauthClientsReloadAll)
+	t.Run("OauthConsents", testOauthConsentsReloadAll)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensReloadAll)
+	t.Run("Outboxes", testOutboxesReloadAll)
+	t.Run("RateLimitBuckets", testRateLimitBucketsReloadAll)
 	t.Run("Users", testUsersReloadAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSelect)
+	t.Run("AuditEvents", testAuditEventsSelect)
+	t.Run("ErasureReceipts", testErasureReceiptsSelect)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesSelect)
+	t.Run("OauthClients", testOauthClientsSelect)
+	t.Run("OauthConsents", testOauthConsentsSelect)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensSelect)
+	t.Run("Outboxes", testOutboxesSelect)
+	t.Run("RateLimitBuckets", testRateLimitBucketsSelect)
 	t.Run("Users", testUsersSelect)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysUpdate)
+	t.Run("AuditEvents", testAuditEventsUpdate)
+	t.Run("ErasureReceipts", testErasureReceiptsUpdate)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesUpdate)
+	t.Run("OauthClients", testOauthClientsUpdate)
+	t.Run("OauthConsents", testOauthConsentsUpdate)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensUpdate)
+	t.Run("Outboxes", testOutboxesUpdate)
+	t.Run("RateLimitBuckets", testRateLimitBucketsUpdate)
 	t.Run("Users", testUsersUpdate)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysSliceUpdateAll)
+	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
+	t.Run("ErasureReceipts", testErasureReceiptsSliceUpdateAll)
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesSliceUpdateAll)
+	t.Run("OauthClients", testOauthClientsSliceUpdateAll)
+	t.Run("OauthConsents", testOauthConsentsSliceUpdateAll)
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensSliceUpdateAll)
+	t.Run("Outboxes", testOutboxesSliceUpdateAll)
+	t.Run("RateLimitBuckets", testRateLimitBucketsSliceUpdateAll)
 	t.Run("Users", testUsersSliceUpdateAll)
+	t.Run("WebhookDeliveries", testWebhookDeliveriesSliceUpdateAll)
 }
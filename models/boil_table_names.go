@@ -4,7 +4,27 @@
 package models
 
 var TableNames = struct {
-	Users string
+	APIKeys                 string
+	AuditEvents             string
+	ErasureReceipts         string
+	OauthAuthorizationCodes string
+	OauthClients            string
+	OauthConsents           string
+	OauthRefreshTokens      string
+	Outbox                  string
+	RateLimitBuckets        string
+	Users                   string
+	WebhookDeliveries       string
 }{
-	Users: "users",
+	APIKeys:                 "api_keys",
+	AuditEvents:             "audit_events",
+	ErasureReceipts:         "erasure_receipts",
+	OauthAuthorizationCodes: "oauth_authorization_codes",
+	OauthClients:            "oauth_clients",
+	OauthConsents:           "oauth_consents",
+	OauthRefreshTokens:      "oauth_refresh_tokens",
+	Outbox:                  "outbox",
+	RateLimitBuckets:        "rate_limit_buckets",
+	Users:                   "users",
+	WebhookDeliveries:       "webhook_deliveries",
 }
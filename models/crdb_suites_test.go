@@ -6,5 +6,25 @@ package models
 import "testing"
 
 func TestUpsert(t *testing.T) {
+	t.Run("APIKeys", testAPIKeysUpsert)
+
+	t.Run("AuditEvents", testAuditEventsUpsert)
+
+	t.Run("ErasureReceipts", testErasureReceiptsUpsert)
+
+	t.Run("OauthAuthorizationCodes", testOauthAuthorizationCodesUpsert)
+
+	t.Run("OauthClients", testOauthClientsUpsert)
+
+	t.Run("OauthConsents", testOauthConsentsUpsert)
+
+	t.Run("OauthRefreshTokens", testOauthRefreshTokensUpsert)
+
+	t.Run("Outboxes", testOutboxesUpsert)
+
+	t.Run("RateLimitBuckets", testRateLimitBucketsUpsert)
+
 	t.Run("Users", testUsersUpsert)
+
+	t.Run("WebhookDeliveries", testWebhookDeliveriesUpsert)
 }
@@ -24,67 +24,53 @@ import (
 
 // User is an object representing the database table.
 type User struct {
-	ID          string    `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Username    string    `boil:"username" json:"username" toml:"username" yaml:"username"`
-	Email       string    `boil:"email" json:"email" toml:"email" yaml:"email"`
-	Password    string    `boil:"password" json:"password" toml:"password" yaml:"password"`
-	IsSuperuser null.Bool `boil:"is_superuser" json:"is_superuser,omitempty" toml:"is_superuser" yaml:"is_superuser,omitempty"`
-	LastLogin   null.Time `boil:"last_login" json:"last_login,omitempty" toml:"last_login" yaml:"last_login,omitempty"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
-	DeletedAt   time.Time `boil:"deleted_at" json:"deleted_at" toml:"deleted_at" yaml:"deleted_at"`
+	ID             string      `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Username       string      `boil:"username" json:"username" toml:"username" yaml:"username"`
+	Email          string      `boil:"email" json:"email" toml:"email" yaml:"email"`
+	Password       string      `boil:"password" json:"password" toml:"password" yaml:"password"`
+	IsSuperuser    null.Bool   `boil:"is_superuser" json:"is_superuser,omitempty" toml:"is_superuser" yaml:"is_superuser,omitempty"`
+	LastLogin      null.Time   `boil:"last_login" json:"last_login,omitempty" toml:"last_login" yaml:"last_login,omitempty"`
+	CreatedAt      time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt      time.Time   `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	DeletedAt      time.Time   `boil:"deleted_at" json:"deleted_at" toml:"deleted_at" yaml:"deleted_at"`
+	AnonymizedAt   null.Time   `boil:"anonymized_at" json:"anonymized_at,omitempty" toml:"anonymized_at" yaml:"anonymized_at,omitempty"`
+	PrincipalType  string      `boil:"principal_type" json:"principal_type" toml:"principal_type" yaml:"principal_type"`
+	CertificateSan null.String `boil:"certificate_san" json:"certificate_san,omitempty" toml:"certificate_san" yaml:"certificate_san,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var UserColumns = struct {
-	ID          string
-	Username    string
-	Email       string
-	Password    string
-	IsSuperuser string
-	LastLogin   string
-	CreatedAt   string
-	UpdatedAt   string
-	DeletedAt   string
+	ID             string
+	Username       string
+	Email          string
+	Password       string
+	IsSuperuser    string
+	LastLogin      string
+	CreatedAt      string
+	UpdatedAt      string
+	DeletedAt      string
+	AnonymizedAt   string
+	PrincipalType  string
+	CertificateSan string
 }{
-	ID:          "id",
-	Username:    "username",
-	Email:       "email",
-	Password:    "password",
-	IsSuperuser: "is_superuser",
-	LastLogin:   "last_login",
-	CreatedAt:   "created_at",
-	UpdatedAt:   "updated_at",
-	DeletedAt:   "deleted_at",
+	ID:             "id",
+	Username:       "username",
+	Email:          "email",
+	Password:       "password",
+	IsSuperuser:    "is_superuser",
+	LastLogin:      "last_login",
+	CreatedAt:      "created_at",
+	UpdatedAt:      "updated_at",
+	DeletedAt:      "deleted_at",
+	AnonymizedAt:   "anonymized_at",
+	PrincipalType:  "principal_type",
+	CertificateSan: "certificate_san",
 }
 
 // Generated where
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpernull_Bool struct{ field string }
 
 func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
@@ -108,78 +94,56 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-type whereHelpernull_Time struct{ field string }
-
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var UserWhere = struct {
-	ID          whereHelperstring
-	Username    whereHelperstring
-	Email       whereHelperstring
-	Password    whereHelperstring
-	IsSuperuser whereHelpernull_Bool
-	LastLogin   whereHelpernull_Time
-	CreatedAt   whereHelpertime_Time
-	UpdatedAt   whereHelpertime_Time
-	DeletedAt   whereHelpertime_Time
+	ID             whereHelperstring
+	Username       whereHelperstring
+	Email          whereHelperstring
+	Password       whereHelperstring
+	IsSuperuser    whereHelpernull_Bool
+	LastLogin      whereHelpernull_Time
+	CreatedAt      whereHelpertime_Time
+	UpdatedAt      whereHelpertime_Time
+	DeletedAt      whereHelpertime_Time
+	AnonymizedAt   whereHelpernull_Time
+	PrincipalType  whereHelperstring
+	CertificateSan whereHelpernull_String
 }{
-	ID:          whereHelperstring{field: "\"users\".\"id\""},
-	Username:    whereHelperstring{field: "\"users\".\"username\""},
-	Email:       whereHelperstring{field: "\"users\".\"email\""},
-	Password:    whereHelperstring{field: "\"users\".\"password\""},
-	IsSuperuser: whereHelpernull_Bool{field: "\"users\".\"is_superuser\""},
-	LastLogin:   whereHelpernull_Time{field: "\"users\".\"last_login\""},
-	CreatedAt:   whereHelpertime_Time{field: "\"users\".\"created_at\""},
-	UpdatedAt:   whereHelpertime_Time{field: "\"users\".\"updated_at\""},
-	DeletedAt:   whereHelpertime_Time{field: "\"users\".\"deleted_at\""},
+	ID:             whereHelperstring{field: "\"users\".\"id\""},
+	Username:       whereHelperstring{field: "\"users\".\"username\""},
+	Email:          whereHelperstring{field: "\"users\".\"email\""},
+	Password:       whereHelperstring{field: "\"users\".\"password\""},
+	IsSuperuser:    whereHelpernull_Bool{field: "\"users\".\"is_superuser\""},
+	LastLogin:      whereHelpernull_Time{field: "\"users\".\"last_login\""},
+	CreatedAt:      whereHelpertime_Time{field: "\"users\".\"created_at\""},
+	UpdatedAt:      whereHelpertime_Time{field: "\"users\".\"updated_at\""},
+	DeletedAt:      whereHelpertime_Time{field: "\"users\".\"deleted_at\""},
+	AnonymizedAt:   whereHelpernull_Time{field: "\"users\".\"anonymized_at\""},
+	PrincipalType:  whereHelperstring{field: "\"users\".\"principal_type\""},
+	CertificateSan: whereHelpernull_String{field: "\"users\".\"certificate_san\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-}{}
+	APIKeys                    string
+	OauthAuthorizationCodes    string
+	ServiceAccountOauthClients string
+	OauthConsents              string
+	OauthRefreshTokens         string
+}{
+	APIKeys:                    "APIKeys",
+	OauthAuthorizationCodes:    "OauthAuthorizationCodes",
+	ServiceAccountOauthClients: "ServiceAccountOauthClients",
+	OauthConsents:              "OauthConsents",
+	OauthRefreshTokens:         "OauthRefreshTokens",
+}
 
 // userR is where relationships are stored.
 type userR struct {
+	APIKeys                    APIKeySlice                 `boil:"APIKeys" json:"APIKeys" toml:"APIKeys" yaml:"APIKeys"`
+	OauthAuthorizationCodes    OauthAuthorizationCodeSlice `boil:"OauthAuthorizationCodes" json:"OauthAuthorizationCodes" toml:"OauthAuthorizationCodes" yaml:"OauthAuthorizationCodes"`
+	ServiceAccountOauthClients OauthClientSlice            `boil:"ServiceAccountOauthClients" json:"ServiceAccountOauthClients" toml:"ServiceAccountOauthClients" yaml:"ServiceAccountOauthClients"`
+	OauthConsents              OauthConsentSlice           `boil:"OauthConsents" json:"OauthConsents" toml:"OauthConsents" yaml:"OauthConsents"`
+	OauthRefreshTokens         OauthRefreshTokenSlice      `boil:"OauthRefreshTokens" json:"OauthRefreshTokens" toml:"OauthRefreshTokens" yaml:"OauthRefreshTokens"`
 }
 
 // NewStruct creates a new relationship struct
@@ -191,9 +155,9 @@ func (*userR) NewStruct() *userR {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "username", "email", "password", "is_superuser", "last_login", "created_at", "updated_at", "deleted_at"}
-	userColumnsWithoutDefault = []string{"username", "email", "password", "last_login", "created_at", "updated_at"}
-	userColumnsWithDefault    = []string{"id", "is_superuser", "deleted_at"}
+	userAllColumns            = []string{"id", "username", "email", "password", "is_superuser", "last_login", "created_at", "updated_at", "deleted_at", "anonymized_at", "principal_type", "certificate_san"}
+	userColumnsWithoutDefault = []string{"username", "email", "password", "last_login", "created_at", "updated_at", "anonymized_at", "certificate_san"}
+	userColumnsWithDefault    = []string{"id", "is_superuser", "deleted_at", "principal_type"}
 	userPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -472,6 +436,936 @@ func (q userQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool,
 	return count > 0, nil
 }
 
+// APIKeys retrieves all the api_key's APIKeys with an executor.
+func (o *User) APIKeys(mods ...qm.QueryMod) apiKeyQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"api_keys\".\"user_id\"=?", o.ID),
+	)
+
+	query := APIKeys(queryMods...)
+	queries.SetFrom(query.Query, "\"api_keys\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"api_keys\".*"})
+	}
+
+	return query
+}
+
+// OauthAuthorizationCodes retrieves all the oauth_authorization_code's OauthAuthorizationCodes with an executor.
+func (o *User) OauthAuthorizationCodes(mods ...qm.QueryMod) oauthAuthorizationCodeQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"oauth_authorization_codes\".\"user_id\"=?", o.ID),
+	)
+
+	query := OauthAuthorizationCodes(queryMods...)
+	queries.SetFrom(query.Query, "\"oauth_authorization_codes\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"oauth_authorization_codes\".*"})
+	}
+
+	return query
+}
+
+// ServiceAccountOauthClients retrieves all the oauth_client's OauthClients with an executor via service_account_id column.
+func (o *User) ServiceAccountOauthClients(mods ...qm.QueryMod) oauthClientQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"oauth_clients\".\"service_account_id\"=?", o.ID),
+	)
+
+	query := OauthClients(queryMods...)
+	queries.SetFrom(query.Query, "\"oauth_clients\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"oauth_clients\".*"})
+	}
+
+	return query
+}
+
+// OauthConsents retrieves all the oauth_consent's OauthConsents with an executor.
+func (o *User) OauthConsents(mods ...qm.QueryMod) oauthConsentQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"oauth_consents\".\"user_id\"=?", o.ID),
+	)
+
+	query := OauthConsents(queryMods...)
+	queries.SetFrom(query.Query, "\"oauth_consents\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"oauth_consents\".*"})
+	}
+
+	return query
+}
+
+// OauthRefreshTokens retrieves all the oauth_refresh_token's OauthRefreshTokens with an executor.
+func (o *User) OauthRefreshTokens(mods ...qm.QueryMod) oauthRefreshTokenQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"oauth_refresh_tokens\".\"user_id\"=?", o.ID),
+	)
+
+	query := OauthRefreshTokens(queryMods...)
+	queries.SetFrom(query.Query, "\"oauth_refresh_tokens\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"oauth_refresh_tokens\".*"})
+	}
+
+	return query
+}
+
+// LoadAPIKeys allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadAPIKeys(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`api_keys`),
+		qm.WhereIn(`api_keys.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load api_keys")
+	}
+
+	var resultSlice []*APIKey
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice api_keys")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on api_keys")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for api_keys")
+	}
+
+	if len(apiKeyAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.APIKeys = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &apiKeyR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.APIKeys = append(local.R.APIKeys, foreign)
+				if foreign.R == nil {
+					foreign.R = &apiKeyR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOauthAuthorizationCodes allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadOauthAuthorizationCodes(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`oauth_authorization_codes`),
+		qm.WhereIn(`oauth_authorization_codes.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oauth_authorization_codes")
+	}
+
+	var resultSlice []*OauthAuthorizationCode
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oauth_authorization_codes")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oauth_authorization_codes")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_authorization_codes")
+	}
+
+	if len(oauthAuthorizationCodeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OauthAuthorizationCodes = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oauthAuthorizationCodeR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.OauthAuthorizationCodes = append(local.R.OauthAuthorizationCodes, foreign)
+				if foreign.R == nil {
+					foreign.R = &oauthAuthorizationCodeR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadServiceAccountOauthClients allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadServiceAccountOauthClients(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`oauth_clients`),
+		qm.WhereIn(`oauth_clients.service_account_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oauth_clients")
+	}
+
+	var resultSlice []*OauthClient
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oauth_clients")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oauth_clients")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_clients")
+	}
+
+	if len(oauthClientAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ServiceAccountOauthClients = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oauthClientR{}
+			}
+			foreign.R.ServiceAccount = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.ServiceAccountID) {
+				local.R.ServiceAccountOauthClients = append(local.R.ServiceAccountOauthClients, foreign)
+				if foreign.R == nil {
+					foreign.R = &oauthClientR{}
+				}
+				foreign.R.ServiceAccount = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOauthConsents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadOauthConsents(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`oauth_consents`),
+		qm.WhereIn(`oauth_consents.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oauth_consents")
+	}
+
+	var resultSlice []*OauthConsent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oauth_consents")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oauth_consents")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_consents")
+	}
+
+	if len(oauthConsentAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OauthConsents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oauthConsentR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.OauthConsents = append(local.R.OauthConsents, foreign)
+				if foreign.R == nil {
+					foreign.R = &oauthConsentR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadOauthRefreshTokens allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadOauthRefreshTokens(ctx context.Context, e boil.ContextExecutor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(
+		qm.From(`oauth_refresh_tokens`),
+		qm.WhereIn(`oauth_refresh_tokens.user_id in ?`, args...),
+	)
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load oauth_refresh_tokens")
+	}
+
+	var resultSlice []*OauthRefreshToken
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice oauth_refresh_tokens")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on oauth_refresh_tokens")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for oauth_refresh_tokens")
+	}
+
+	if len(oauthRefreshTokenAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.OauthRefreshTokens = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &oauthRefreshTokenR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.OauthRefreshTokens = append(local.R.OauthRefreshTokens, foreign)
+				if foreign.R == nil {
+					foreign.R = &oauthRefreshTokenR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddAPIKeys adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.APIKeys.
+// Sets related.R.User appropriately.
+func (o *User) AddAPIKeys(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*APIKey) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"api_keys\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, apiKeyPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			APIKeys: related,
+		}
+	} else {
+		o.R.APIKeys = append(o.R.APIKeys, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &apiKeyR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddOauthAuthorizationCodes adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.OauthAuthorizationCodes.
+// Sets related.R.User appropriately.
+func (o *User) AddOauthAuthorizationCodes(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthAuthorizationCode) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"oauth_authorization_codes\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oauthAuthorizationCodePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			OauthAuthorizationCodes: related,
+		}
+	} else {
+		o.R.OauthAuthorizationCodes = append(o.R.OauthAuthorizationCodes, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oauthAuthorizationCodeR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddServiceAccountOauthClients adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.ServiceAccountOauthClients.
+// Sets related.R.ServiceAccount appropriately.
+func (o *User) AddServiceAccountOauthClients(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthClient) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.ServiceAccountID, o.ID)
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"oauth_clients\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"service_account_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oauthClientPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.ServiceAccountID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			ServiceAccountOauthClients: related,
+		}
+	} else {
+		o.R.ServiceAccountOauthClients = append(o.R.ServiceAccountOauthClients, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oauthClientR{
+				ServiceAccount: o,
+			}
+		} else {
+			rel.R.ServiceAccount = o
+		}
+	}
+	return nil
+}
+
+// SetServiceAccountOauthClients removes all previously related items of the
+// user replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.ServiceAccount's ServiceAccountOauthClients accordingly.
+// Replaces o.R.ServiceAccountOauthClients with related.
+// Sets related.R.ServiceAccount's ServiceAccountOauthClients accordingly.
+func (o *User) SetServiceAccountOauthClients(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthClient) error {
+	query := "update \"oauth_clients\" set \"service_account_id\" = null where \"service_account_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.IsDebug(ctx) {
+		writer := boil.DebugWriterFrom(ctx)
+		fmt.Fprintln(writer, query)
+		fmt.Fprintln(writer, values)
+	}
+	_, err := exec.ExecContext(ctx, query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.ServiceAccountOauthClients {
+			queries.SetScanner(&rel.ServiceAccountID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.ServiceAccount = nil
+		}
+
+		o.R.ServiceAccountOauthClients = nil
+	}
+	return o.AddServiceAccountOauthClients(ctx, exec, insert, related...)
+}
+
+// RemoveServiceAccountOauthClients relationships from objects passed in.
+// Removes related items from R.ServiceAccountOauthClients (uses pointer comparison, removal does not keep order)
+// Sets related.R.ServiceAccount.
+func (o *User) RemoveServiceAccountOauthClients(ctx context.Context, exec boil.ContextExecutor, related ...*OauthClient) error {
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.ServiceAccountID, nil)
+		if rel.R != nil {
+			rel.R.ServiceAccount = nil
+		}
+		if _, err = rel.Update(ctx, exec, boil.Whitelist("service_account_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.ServiceAccountOauthClients {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.ServiceAccountOauthClients)
+			if ln > 1 && i < ln-1 {
+				o.R.ServiceAccountOauthClients[i] = o.R.ServiceAccountOauthClients[ln-1]
+			}
+			o.R.ServiceAccountOauthClients = o.R.ServiceAccountOauthClients[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddOauthConsents adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.OauthConsents.
+// Sets related.R.User appropriately.
+func (o *User) AddOauthConsents(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthConsent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"oauth_consents\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oauthConsentPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.UserID, rel.ClientID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			OauthConsents: related,
+		}
+	} else {
+		o.R.OauthConsents = append(o.R.OauthConsents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oauthConsentR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddOauthRefreshTokens adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.OauthRefreshTokens.
+// Sets related.R.User appropriately.
+func (o *User) AddOauthRefreshTokens(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*OauthRefreshToken) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"oauth_refresh_tokens\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, oauthRefreshTokenPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.IsDebug(ctx) {
+				writer := boil.DebugWriterFrom(ctx)
+				fmt.Fprintln(writer, updateQuery)
+				fmt.Fprintln(writer, values)
+			}
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			OauthRefreshTokens: related,
+		}
+	} else {
+		o.R.OauthRefreshTokens = append(o.R.OauthRefreshTokens, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &oauthRefreshTokenR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))
@@ -0,0 +1,245 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+	"user.app/message"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/conn"
+	"user.app/pkg/constants"
+	"user.app/pkg/events"
+	"user.app/pkg/logging"
+	"user.app/pkg/query"
+)
+
+var ErrAPIKeyNotFound = status.Error(codes.NotFound, "API key not found")
+
+// methodScopes is the scope an API key or an OAuth2 access token needs to call the method, the methods missing here can
+// only be called with a session. The scopes do not imply each other.
+var methodScopes = map[string]string{
+	"/message.UserApp/ExportMyData":          auth.ScopeRead,
+	"/message.UserApp/ListAPIKeys":           auth.ScopeRead,
+	"/message.UserApp/UpdateUser":            auth.ScopeWrite,
+	"/message.UserApp/RestoreUser":           auth.ScopeAdmin,
+	"/message.UserApp/ExportUserData":        auth.ScopeAdmin,
+	"/message.UserApp/EraseUser":             auth.ScopeAdmin,
+	"/message.UserApp/ListAuditEvents":       auth.ScopeAdmin,
+	"/message.UserApp/ListWebhookDeliveries": auth.ScopeAdmin,
+	"/message.UserApp/ReplayWebhookDelivery": auth.ScopeAdmin,
+	"/message.UserApp/WatchUserEvents":       auth.ScopeAdmin,
+	"/message.UserApp/CreateServiceAccount":  auth.ScopeAdmin,
+	"/message.UserApp/ListServiceAccounts":   auth.ScopeAdmin,
+	"/message.UserApp/DeleteServiceAccount":  auth.ScopeAdmin,
+	// an admin key can mint keys for the service accounts, like the admins it belongs to
+	"/message.UserApp/CreateServiceAccountAPIKey": auth.ScopeAdmin,
+	"/message.UserApp/ListServiceAccountAPIKeys":  auth.ScopeAdmin,
+	"/message.UserApp/RevokeServiceAccountAPIKey": auth.ScopeAdmin,
+}
+
+func (*Server) CreateAPIKey(ctx context.Context, req *message.CreateAPIKeyRequest) (*message.CreateAPIKeyResponse, error) {
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	scopes := uniqueScopes(req.Scopes)
+	if hasScope(scopes, auth.ScopeAdmin) {
+		if isSuperUser, err := MDIsSuperUser(ctx); err != nil || !isSuperUser {
+			return nil, ErrPermissionDenied
+		}
+	}
+	return createAPIKey(ctx, userID, userID, req.Name, scopes, req.ExpiresAt)
+}
+
+func (*Server) ListAPIKeys(ctx context.Context, _ *message.Empty) (*message.ListAPIKeysResponse, error) {
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	return listAPIKeys(ctx, userID)
+}
+
+func (*Server) RevokeAPIKey(ctx context.Context, req *message.RevokeAPIKeyRequest) (*message.Empty, error) {
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	return revokeAPIKey(ctx, userID, userID, req.KeyId)
+}
+
+// createAPIKey creates the API key of the owner, a user or a service account, and returns it with its secret
+func createAPIKey(ctx context.Context, ownerID, actorID, name string, scopes []string, expiresAtProto *timestamp.Timestamp) (*message.CreateAPIKeyResponse, error) {
+	apiKey := &models.APIKey{
+		UserID: ownerID,
+		Name:   name,
+		Scopes: types.StringArray(scopes),
+	}
+	if expiresAtProto != nil {
+		expiresAt, err := ptypes.Timestamp(expiresAtProto)
+		if err != nil || !expiresAt.After(time.Now()) {
+			return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+		}
+		apiKey.ExpiresAt = null.TimeFrom(expiresAt)
+	}
+
+	key, prefix, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot generate the API key")
+		return nil, ErrInternalServer
+	}
+	apiKey.Prefix = prefix
+	apiKey.Hash = hash
+
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		if err := query.CreateAPIKey(ctx, tx, apiKey); err != nil {
+			return err
+		}
+		return events.DefaultBus.Publish(ctx, tx, events.Event{
+			Type:    events.APIKeyCreated,
+			UserID:  ownerID,
+			ActorID: actorID,
+		})
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot create the API key")
+		return nil, ErrInternalServer
+	}
+
+	msg, err := apiKeyMessage(apiKey)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the API key")
+		return nil, ErrInternalServer
+	}
+	return &message.CreateAPIKeyResponse{
+		ApiKey: msg,
+		Key:    key,
+	}, nil
+}
+
+// listAPIKeys returns the API keys of the owner that have not been revoked
+func listAPIKeys(ctx context.Context, ownerID string) (*message.ListAPIKeysResponse, error) {
+	apiKeys, err := query.ListUserAPIKeys(ctx, conn.Instance, ownerID)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot list the API keys")
+		return nil, ErrInternalServer
+	}
+
+	res := &message.ListAPIKeysResponse{}
+	for _, apiKey := range apiKeys {
+		msg, err := apiKeyMessage(apiKey)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the API key")
+			return nil, ErrInternalServer
+		}
+		res.ApiKeys = append(res.ApiKeys, msg)
+	}
+	return res, nil
+}
+
+// revokeAPIKey revokes the API key of the owner
+func revokeAPIKey(ctx context.Context, ownerID, actorID, keyID string) (*message.Empty, error) {
+	if _, err := uuid.FromString(keyID); err != nil {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	err := conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		if err := query.RevokeAPIKey(ctx, tx, ownerID, keyID, time.Now()); err != nil {
+			return err
+		}
+		return events.DefaultBus.Publish(ctx, tx, events.Event{
+			Type:    events.APIKeyRevoked,
+			UserID:  ownerID,
+			ActorID: actorID,
+		})
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot revoke the API key")
+		if errors.Cause(err) == query.ErrNoRowsFound {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, ErrInternalServer
+	}
+	return &message.Empty{}, nil
+}
+
+// uniqueScopes drops the scopes requested twice
+func uniqueScopes(requested []string) []string {
+	var scopes []string
+	for _, scope := range requested {
+		if !hasScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// checkScope fails when the call is authenticated with an API key or an OAuth2 access token missing the scope of the
+// method
+func checkScope(ctx context.Context, fullMethodName string) error {
+	md, err := MDGet(ctx)
+	if err != nil {
+		return err
+	}
+	credentials := "API key"
+	if len(md.Get(constants.MDKeyClientID)) > 0 {
+		credentials = "access token"
+	} else if len(md.Get(constants.MDKeyAPIKeyID)) == 0 {
+		return nil
+	}
+
+	scope, ok := methodScopes[fullMethodName]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "the method cannot be called with an %s", credentials)
+	}
+	if hasScope(md.Get(constants.MDKeyScopes), scope) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "the %s does not have the %s scope", credentials, scope)
+}
+
+// apiKeyMessage converts the API key to its message, the hash is never sent
+func apiKeyMessage(apiKey *models.APIKey) (*message.APIKey, error) {
+	msg := &message.APIKey{
+		Id:     apiKey.ID,
+		Name:   apiKey.Name,
+		Prefix: apiKey.Prefix,
+		Scopes: apiKey.Scopes,
+	}
+
+	var err error
+	if msg.CreatedAt, err = ptypes.TimestampProto(apiKey.CreatedAt); err != nil {
+		return nil, err
+	}
+	if apiKey.ExpiresAt.Valid {
+		if msg.ExpiresAt, err = ptypes.TimestampProto(apiKey.ExpiresAt.Time); err != nil {
+			return nil, err
+		}
+	}
+	if apiKey.LastUsedAt.Valid {
+		if msg.LastUsedAt, err = ptypes.TimestampProto(apiKey.LastUsedAt.Time); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
@@ -2,7 +2,11 @@ package api
 
 import (
 	"context"
-	"github.com/rs/zerolog/log"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -13,25 +17,84 @@ import (
 	"user.app/pkg/auth"
 	"user.app/pkg/conn"
 	"user.app/pkg/constants"
+	"user.app/pkg/erasure"
+	"user.app/pkg/export"
+	"user.app/pkg/logging"
+	"user.app/pkg/metrics"
+	"user.app/pkg/normalize"
+	"user.app/pkg/outbox"
 	"user.app/pkg/query"
+	"user.app/pkg/retention"
+	"user.app/pkg/transport"
+	"user.app/pkg/webhook"
 )
 
 var (
 	ErrInternalServer     = status.Error(codes.Internal, "internal server error")
 	ErrUserIDNotAvailable = status.Error(codes.Internal, "user id not available")
+	ErrUserAlreadyExists  = status.Error(codes.AlreadyExists, "username or email already taken")
+	ErrPermissionDenied   = status.Error(codes.PermissionDenied, "permission denied")
+	ErrPendingRestore     = status.Error(codes.PermissionDenied, "the account is deleted, restore it first")
+	ErrNotRestorable      = status.Error(codes.NotFound, "no restorable user found")
+	ErrDeliveryNotFound   = status.Error(codes.NotFound, "webhook delivery not found")
+	ErrShuttingDown       = status.Error(codes.Unavailable, "the server is shutting down, resume from the last cursor")
 )
 
+const (
+	// defaultPageSize is used when a list is requested without a page size
+	defaultPageSize = 50
+	// maxPageSize caps the page size of the lists
+	maxPageSize = 500
+)
+
+// PublicMethods are the methods called without a session
+var PublicMethods = map[string]bool{
+	"/message.UserApp/CreateUser": true,
+	"/message.UserApp/SignIn":     true,
+}
+
+// identityKeys are set by the authentication, the values sent by the clients are dropped
+var identityKeys = []string{
+	constants.MDKeyUserID,
+	constants.MDKeyUsername,
+	constants.MDKeySuperUser,
+	constants.MDKeyPrincipalType,
+	constants.MDKeyPendingRestore,
+	constants.MDKeyAPIKeyID,
+	constants.MDKeyClientID,
+	constants.MDKeyScopes,
+}
+
+// pendingRestoreMethods are the only methods a session pending restore can call
+var pendingRestoreMethods = map[string]bool{
+	"/message.UserApp/RestoreAccount": true,
+	"/message.UserApp/EraseMyAccount": true,
+	"/message.UserApp/SignOut":        true,
+}
+
+// humanMethods are the methods the service accounts cannot call, they have no password nor session and their
+// lifecycle and API keys are managed by the admins
+var humanMethods = map[string]bool{
+	"/message.UserApp/SignOut":        true,
+	"/message.UserApp/UpdateUser":     true,
+	"/message.UserApp/DeleteUser":     true,
+	"/message.UserApp/RestoreAccount": true,
+	"/message.UserApp/EraseMyAccount": true,
+	"/message.UserApp/CreateAPIKey":   true,
+	"/message.UserApp/ListAPIKeys":    true,
+	"/message.UserApp/RevokeAPIKey":   true,
+}
+
 // MDGet returns the metadata object present in the incoming context
 func MDGet(ctx context.Context) (metadata.MD, error) {
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		return md, nil
 	}
-	log.Error().Msg("unable to get metadata from the context")
+	logging.Ctx(ctx).Error().Msg("unable to get metadata from the context")
 	return nil, status.Error(codes.Internal, "Internal server error")
 }
 
-// MDGetUserIsServiceAccount returns the user, is service account or not
-// present in the context which is supposed to be the logged-in user's
+// MDIsSuperUser returns whether the logged-in user, or service account, is a superuser
 func MDIsSuperUser(ctx context.Context) (bool, error) {
 	is, err := MDGetValue(ctx, constants.MDKeySuperUser)
 	if err != nil {
@@ -40,13 +103,32 @@ func MDIsSuperUser(ctx context.Context) (bool, error) {
 
 	isBool, err := strconv.ParseBool(is)
 	if err != nil {
-		log.Error().Err(err).Msg("unable to parse is_service_account metadata")
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to parse super_user metadata")
 		return false, err
 	}
 
 	return isBool, nil
 }
 
+// MDIsServiceAccount returns whether the caller is a service account, authenticated with an API key, a client
+// certificate or its client credentials
+func MDIsServiceAccount(ctx context.Context) (bool, error) {
+	principalType, err := MDGetValue(ctx, constants.MDKeyPrincipalType)
+	if err != nil {
+		return false, err
+	}
+	return principalType == constants.PrincipalService, nil
+}
+
+// MDIsPendingRestore returns whether the logged-in user is deleted and can only restore its account
+func MDIsPendingRestore(ctx context.Context) (bool, error) {
+	is, err := MDGetValue(ctx, constants.MDKeyPendingRestore)
+	if err != nil {
+		return false, err
+	}
+	return strconv.ParseBool(is)
+}
+
 // MDGetUserID returns the user id present in the context which is supposed to be the logged-in user's id
 func MDGetUserID(ctx context.Context) (string, error) {
 	return MDGetValue(ctx, constants.MDKeyUserID)
@@ -62,12 +144,14 @@ func MDGetValue(ctx context.Context, key string) (string, error) {
 	if values := md.Get(key); len(values) > 0 {
 		return values[0], nil
 	}
-	log.Error().Msgf("error while getting %s from metadata", key)
+	logging.Ctx(ctx).Error().Msgf("error while getting %s from metadata", key)
 
 	return "", status.Error(codes.Internal, "Internal server error")
 }
 
 type Server struct {
+	// Shutdown is closed when the server starts draining, the streams that never end on their own are closed then
+	Shutdown <-chan struct{}
 }
 
 func (*Server) SignIn(ctx context.Context, req *message.AuthRequest) (*message.AuthResponse, error) {
@@ -76,7 +160,7 @@ func (*Server) SignIn(ctx context.Context, req *message.AuthRequest) (*message.A
 	}
 	userDetails, err := auth.Authenticator.Authenticate(ctx, req)
 	if err != nil {
-		log.Error().Err(err).Msg("cannot authenticate the user")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot authenticate the user")
 		if err == auth.ErrInvalidCredentialsError {
 			return nil, status.Error(codes.Unauthenticated, "Invalid credentials error")
 		}
@@ -85,20 +169,21 @@ func (*Server) SignIn(ctx context.Context, req *message.AuthRequest) (*message.A
 
 	token, err := auth.Authenticator.EncodeToken(userDetails.User, userDetails.ClaimType, userDetails.SessionID)
 	if err != nil {
-		log.Error().Err(err).Msg("unable to encode jwt")
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to encode jwt")
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
 	return &message.AuthResponse{
-		Username: userDetails.User.Username,
-		Token:    token.AccessToken,
+		Username:       userDetails.User.Username,
+		Token:          token.AccessToken,
+		PendingRestore: userDetails.PendingRestore,
 	}, nil
 }
 
 func (*Server) SignOut(ctx context.Context, _ *message.Empty) (*message.Empty, error) {
 	err := auth.Authenticator.InvalidateSession(ctx)
 	if err != nil {
-		log.Error().Err(err).Msg("cannot invalidate the session")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot invalidate the session")
 		return nil, ErrInternalServer
 	}
 
@@ -106,35 +191,46 @@ func (*Server) SignOut(ctx context.Context, _ *message.Empty) (*message.Empty, e
 }
 
 func (*Server) CreateUser(ctx context.Context, req *message.CreateUserRequest) (*message.CreateUserResponse, error) {
-	hashedPW, err := auth.PasslibCtx.Hash(req.Password)
+	// the invalid requests are rejected before paying for the argon2 hash
+	username, err := normalize.Username(req.Username)
 	if err != nil {
-		log.Error().Err(err).Msg("cannot hash the password")
-		return nil, ErrInternalServer
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	transaction, err := conn.Instance.Begin()
+	email, err := normalize.Email(req.Email)
 	if err != nil {
-		log.Error().Err(err).Msg("cannot begin the transaction")
-		return nil, ErrInternalServer
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	newUser := &models.User{
-		Username: req.Username,
-		Email:    req.Email,
-		Password: hashedPW,
+	hashedPW, err := auth.HashPassword(ctx, req.Password)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot hash the password")
+		return nil, ErrInternalServer
 	}
 
-	err = query.CreateUser(ctx, transaction, newUser)
+	newUser := &models.User{}
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		if err := checkReuseCooldown(ctx, tx, username, email); err != nil {
+			return err
+		}
+		*newUser = models.User{
+			Username: username,
+			Email:    email,
+			Password: hashedPW,
+		}
+		return query.CreateUser(ctx, tx, newUser)
+	})
 	if err != nil {
-		_ = transaction.Rollback()
-		log.Error().Err(err).Msg("cannot create the user")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot create the user")
+		if errors.Cause(err) == query.ErrUsernameOrEmailTaken {
+			return nil, ErrUserAlreadyExists
+		}
 		return nil, ErrInternalServer
 	}
-	_ = transaction.Commit()
+	metrics.SignUp()
 
 	return &message.CreateUserResponse{
 		UserId: newUser.ID,
-	}, err
+	}, nil
 }
 
 func (*Server) UpdateUser(ctx context.Context, req *message.UpdateUserRequest) (*message.Empty, error) {
@@ -143,95 +239,599 @@ func (*Server) UpdateUser(ctx context.Context, req *message.UpdateUserRequest) (
 		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
 	}
 
-	if len(req.Username) == 0 && len(req.Email) == 0 {
-		return nil, status.Error(codes.InvalidArgument, "noting to update")
+	var username, email string
+	if len(req.Username) > 0 {
+		if username, err = normalize.Username(req.Username); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
-
-	transaction, err := conn.Instance.Begin()
-	if err != nil {
-		log.Error().Err(err).Msg("cannot begin the transaction")
-		return nil, ErrInternalServer
+	if len(req.Email) > 0 {
+		if email, err = normalize.Email(req.Email); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
 
-	user, err := query.FindUser(ctx, transaction, &query.UserFilter{
-		ID: userID,
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		user, err := query.FindUser(ctx, tx, &query.UserFilter{
+			ID: userID,
+		})
+		if err != nil {
+			return errors.WithMessage(err, "cannot retrieve the user")
+		}
+
+		var (
+			columnsUpdated                []string
+			changedUsername, changedEmail string
+		)
+
+		if len(email) > 0 && email != user.Email {
+			user.Email = email
+			changedEmail = email
+			columnsUpdated = append(columnsUpdated, models.UserColumns.Email)
+		}
+
+		if len(username) > 0 && username != user.Username {
+			user.Username = username
+			changedUsername = username
+			columnsUpdated = append(columnsUpdated, models.UserColumns.Username)
+		}
+
+		if len(columnsUpdated) == 0 {
+			return nil
+		}
+		if err := checkReuseCooldown(ctx, tx, changedUsername, changedEmail); err != nil {
+			return err
+		}
+		return query.UpdateUser(ctx, tx, user, columnsUpdated)
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("cannot retrieve the user")
-		_ = transaction.Rollback()
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot update the user")
+		if errors.Cause(err) == query.ErrUsernameOrEmailTaken {
+			return nil, ErrUserAlreadyExists
+		}
 		return nil, ErrInternalServer
 	}
 
-	var columnsUpdated []string
+	return &message.Empty{}, nil
+}
 
-	if req.Email != user.Email {
-		user.Email = req.Email
-		columnsUpdated = append(columnsUpdated, models.UserColumns.Email)
+func (*Server) DeleteUser(ctx context.Context, _ *message.Empty) (*message.Empty, error) {
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
 	}
 
-	if req.Username != user.Username {
-		user.Username = req.Username
-		columnsUpdated = append(columnsUpdated, models.UserColumns.Username)
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		user, err := query.FindUser(ctx, tx, &query.UserFilter{
+			ID: userID,
+		})
+		if err != nil {
+			return errors.WithMessage(err, "cannot retrieve the user")
+		}
+
+		user.DeletedAt = time.Now()
+		return query.UpdateUser(ctx, tx, user, []string{models.UserColumns.DeletedAt})
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot delete the user")
+		return nil, ErrInternalServer
 	}
+	metrics.Deletion()
 
-	err = query.UpdateUser(ctx, transaction, user, columnsUpdated)
+	err = auth.Authenticator.InvalidateSession(ctx)
 	if err != nil {
-		_ = transaction.Rollback()
-		log.Error().Err(err).Msg("cannot update the user")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot invalidate the session")
 		return nil, ErrInternalServer
 	}
-	_ = transaction.Commit()
 
 	return &message.Empty{}, err
 }
 
-func (*Server) DeleteUser(ctx context.Context, _ *message.Empty) (*message.Empty, error) {
+func (*Server) RestoreAccount(ctx context.Context, _ *message.Empty) (*message.Empty, error) {
 	userID, err := MDGetUserID(ctx)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
 	}
 
-	transaction, err := conn.Instance.Begin()
-	if err != nil {
-		log.Error().Err(err).Msg("cannot begin the transaction")
+	if err = restoreUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err = auth.Authenticator.CompleteRestore(ctx); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot update the session")
 		return nil, ErrInternalServer
 	}
+	return &message.Empty{}, nil
+}
+
+func (*Server) RestoreUser(ctx context.Context, req *message.RestoreUserRequest) (*message.Empty, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
 
-	user, err := query.FindUser(ctx, transaction, &query.UserFilter{
+	if len(req.UserId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is not present in the request")
+	}
+
+	if err = restoreUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+	return &message.Empty{}, nil
+}
+
+func (*Server) ExportMyData(req *message.ExportRequest, stream message.UserApp_ExportMyDataServer) error {
+	ctx := stream.Context()
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	user, err := query.FindUser(ctx, conn.Instance, &query.UserFilter{
 		ID: userID,
 	})
 	if err != nil {
-		log.Error().Err(err).Msg("cannot retrieve the user")
-		_ = transaction.Rollback()
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the user")
+		return ErrInternalServer
+	}
+
+	return exportUser(ctx, user, req.Format, stream)
+}
+
+func (*Server) ExportUserData(req *message.ExportUserDataRequest, stream message.UserApp_ExportUserDataServer) error {
+	ctx := stream.Context()
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return ErrPermissionDenied
+	}
+
+	if len(req.UserId) == 0 {
+		return status.Error(codes.InvalidArgument, "user_id is not present in the request")
+	}
+
+	user, err := models.FindUser(ctx, conn.Instance, req.UserId)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the user")
+		if errors.Cause(err) == sql.ErrNoRows {
+			return status.Error(codes.NotFound, "user not found")
+		}
+		return ErrInternalServer
+	}
+
+	return exportUser(ctx, user, req.Format, stream)
+}
+
+func (*Server) EraseMyAccount(ctx context.Context, _ *message.Empty) (*message.ErasureReceipt, error) {
+	userID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	return eraseUser(ctx, userID, erasure.TriggerUser, userID)
+}
+
+func (*Server) EraseUser(ctx context.Context, req *message.EraseUserRequest) (*message.ErasureReceipt, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	if len(req.UserId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is not present in the request")
+	}
+
+	adminID, err := MDGetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "userID is not present in the request")
+	}
+
+	return eraseUser(ctx, req.UserId, erasure.TriggerAdmin, adminID)
+}
+
+// eraseUser erases the personal data of the user and returns the receipt
+func eraseUser(ctx context.Context, userID, trigger, requestedBy string) (*message.ErasureReceipt, error) {
+	receipt, err := erasure.Erase(ctx, conn.Instance, userID, trigger, requestedBy)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot erase the user")
+		if errors.Cause(err) == erasure.ErrUserNotFound {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		return nil, ErrInternalServer
+	}
+
+	erasedAt, err := ptypes.TimestampProto(receipt.ErasedAt)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the erasure time")
+		return nil, ErrInternalServer
+	}
+
+	return &message.ErasureReceipt{
+		ReceiptId: receipt.ID,
+		UserId:    receipt.UserID,
+		Trigger:   receipt.Trigger,
+		ErasedAt:  erasedAt,
+	}, nil
+}
+
+func (*Server) ListAuditEvents(ctx context.Context, req *message.ListAuditEventsRequest) (*message.ListAuditEventsResponse, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	var afterSeq int64
+	if len(req.PageToken) > 0 {
+		if afterSeq, err = strconv.ParseInt(req.PageToken, 10, 64); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+	limit := pageSize(req.PageSize)
+
+	events, err := query.ListAuditEvents(ctx, conn.Instance, &query.AuditEventFilter{
+		ActorID:      req.ActorId,
+		TargetUserID: req.TargetUserId,
+	}, afterSeq, limit)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot list the audit events")
 		return nil, ErrInternalServer
 	}
 
-	var columnsUpdated []string
+	res := &message.ListAuditEventsResponse{}
+	for _, event := range events {
+		createdAt, err := ptypes.TimestampProto(event.CreatedAt)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the event time")
+			return nil, ErrInternalServer
+		}
+		res.Events = append(res.Events, &message.AuditEvent{
+			Id:            event.ID,
+			Seq:           event.Seq,
+			ActorId:       event.ActorID.String,
+			TargetUserId:  event.TargetUserID.String,
+			Action:        event.Action,
+			ChangedFields: event.ChangedFields,
+			RequestId:     event.RequestID,
+			ClientIp:      event.ClientIP,
+			CreatedAt:     createdAt,
+			Hash:          hex.EncodeToString(event.Hash),
+		})
+	}
+	if len(events) == limit {
+		res.NextPageToken = strconv.FormatInt(events[len(events)-1].Seq, 10)
+	}
+	return res, nil
+}
 
-	user.DeletedAt = time.Now()
-	columnsUpdated = append(columnsUpdated, models.UserColumns.DeletedAt)
+func (*Server) ListWebhookDeliveries(ctx context.Context, req *message.ListWebhookDeliveriesRequest) (*message.ListWebhookDeliveriesResponse, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	deliveryStatus := req.Status
+	switch deliveryStatus {
+	case "":
+		deliveryStatus = outbox.StatusDead
+	case outbox.StatusPending, outbox.StatusDelivered, outbox.StatusDead:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "status must be pending, delivered or dead")
+	}
 
-	err = query.UpdateUser(ctx, transaction, user, columnsUpdated)
+	var offset int
+	if len(req.PageToken) > 0 {
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+	limit := pageSize(req.PageSize)
+
+	deliveries, err := query.ListWebhookDeliveries(ctx, conn.Instance, deliveryStatus, offset, limit)
 	if err != nil {
-		_ = transaction.Rollback()
-		log.Error().Err(err).Msg("cannot update the user")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot list the webhook deliveries")
 		return nil, ErrInternalServer
 	}
-	_ = transaction.Commit()
 
-	err = auth.Authenticator.InvalidateSession(ctx)
+	res := &message.ListWebhookDeliveriesResponse{}
+	for _, delivery := range deliveries {
+		msg, err := webhookDeliveryMessage(delivery)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the webhook delivery")
+			return nil, ErrInternalServer
+		}
+		res.Deliveries = append(res.Deliveries, msg)
+	}
+	if len(deliveries) == limit {
+		res.NextPageToken = strconv.Itoa(offset + limit)
+	}
+	return res, nil
+}
+
+func (*Server) ReplayWebhookDelivery(ctx context.Context, req *message.ReplayWebhookDeliveryRequest) (*message.WebhookDelivery, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	if len(req.DeliveryId) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "delivery_id is not present in the request")
+	}
+
+	delivery, err := webhook.Replay(ctx, conn.Instance, req.DeliveryId)
 	if err != nil {
-		log.Error().Err(err).Msg("cannot invalidate the session")
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot replay the webhook delivery")
+		switch errors.Cause(err) {
+		case webhook.ErrDeliveryNotFound:
+			return nil, ErrDeliveryNotFound
+		case webhook.ErrDeliveryPending:
+			return nil, status.Error(codes.FailedPrecondition, "the delivery is still pending")
+		}
 		return nil, ErrInternalServer
 	}
 
-	return &message.Empty{}, err
+	msg, err := webhookDeliveryMessage(delivery)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the webhook delivery")
+		return nil, ErrInternalServer
+	}
+	return msg, nil
+}
+
+func (s *Server) WatchUserEvents(req *message.WatchUserEventsRequest, stream message.UserApp_WatchUserEventsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	shuttingDown := make(chan struct{})
+	go func() {
+		select {
+		case <-s.Shutdown:
+			close(shuttingDown)
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// the admins and the service accounts can watch, the API keys and the access tokens also need the admin scope
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil {
+		return ErrPermissionDenied
+	}
+	if !isSuperUser {
+		isServiceAccount, err := MDIsServiceAccount(ctx)
+		if err != nil || !isServiceAccount {
+			return ErrPermissionDenied
+		}
+	}
+
+	cursor := int64(-1)
+	if len(req.Cursor) > 0 {
+		if cursor, err = strconv.ParseInt(req.Cursor, 10, 64); err != nil || cursor < 0 {
+			return status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+	}
+
+	err = outbox.Watch(ctx, conn.Instance, cursor, req.Types, func(event *models.Outbox) error {
+		var msg outbox.Message
+		if err := json.Unmarshal(event.Payload, &msg); err != nil {
+			return errors.Wrap(err, "cannot unmarshal the outbox message")
+		}
+		occurredAt, err := ptypes.TimestampProto(msg.OccurredAt)
+		if err != nil {
+			return errors.Wrap(err, "cannot convert the event time")
+		}
+		return stream.Send(&message.UserEvent{
+			Cursor:         strconv.FormatInt(event.Seq, 10),
+			Id:             event.ID,
+			Type:           event.EventType,
+			UserId:         event.UserID,
+			ActorId:        msg.ActorID,
+			ChangedColumns: msg.ChangedColumns,
+			OccurredAt:     occurredAt,
+		})
+	})
+	switch {
+	case errors.Cause(err) == outbox.ErrCursorExpired:
+		return status.Error(codes.OutOfRange, "the events after the cursor have been pruned, resume with an empty cursor")
+	case errors.Cause(err) == context.Canceled || status.Code(errors.Cause(err)) == codes.Canceled:
+		select {
+		case <-shuttingDown:
+			return ErrShuttingDown
+		default:
+			return nil
+		}
+	case err != nil:
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot watch the user events")
+		return ErrInternalServer
+	}
+	return nil
+}
+
+// webhookDeliveryMessage converts the delivery and its loaded outbox event to its message
+func webhookDeliveryMessage(delivery *models.WebhookDelivery) (*message.WebhookDelivery, error) {
+	msg := &message.WebhookDelivery{
+		Id:        delivery.ID,
+		EventId:   delivery.OutboxID,
+		Endpoint:  delivery.Endpoint,
+		Status:    delivery.Status,
+		Attempts:  int32(delivery.Attempts),
+		LastError: delivery.LastError,
+	}
+	if delivery.R != nil && delivery.R.Outbox != nil {
+		msg.EventType = delivery.R.Outbox.EventType
+		msg.UserId = delivery.R.Outbox.UserID
+	}
+
+	var err error
+	if msg.NextAttemptAt, err = ptypes.TimestampProto(delivery.NextAttemptAt); err != nil {
+		return nil, err
+	}
+	if msg.CreatedAt, err = ptypes.TimestampProto(delivery.CreatedAt); err != nil {
+		return nil, err
+	}
+	if delivery.DeliveredAt.Valid {
+		if msg.DeliveredAt, err = ptypes.TimestampProto(delivery.DeliveredAt.Time); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// pageSize returns the requested page size, defaultPageSize when it is not set, capped at maxPageSize
+func pageSize(requested int32) int {
+	if requested <= 0 {
+		return defaultPageSize
+	}
+	if requested > maxPageSize {
+		return maxPageSize
+	}
+	return int(requested)
+}
+
+// exportUser assembles the export document of the user and streams it
+func exportUser(ctx context.Context, user *models.User, format message.ExportFormat, sender export.ChunkSender) error {
+	sessions, err := auth.Authenticator.ListUserSessions(ctx, user.ID)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot list the sessions")
+		return ErrInternalServer
+	}
+
+	records, err := exportRecords(ctx, user.ID)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the records of the export")
+		return ErrInternalServer
+	}
+
+	doc := export.Build(user, sessions, records, query.NotDeleted)
+	if err = export.Stream(doc, format, sender); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot stream the export")
+		return ErrInternalServer
+	}
+	return nil
+}
+
+// exportRecords retrieves the audit events, the API keys and the OAuth2 consents of the user
+func exportRecords(ctx context.Context, userID string) (export.Records, error) {
+	var records export.Records
+	for afterSeq := int64(0); ; {
+		events, err := query.ListAuditEvents(ctx, conn.Instance, &query.AuditEventFilter{TargetUserID: userID}, afterSeq,
+			maxPageSize)
+		if err != nil {
+			return records, errors.WithMessage(err, "cannot list the audit events")
+		}
+		records.AuditEvents = append(records.AuditEvents, events...)
+		if len(events) < maxPageSize {
+			break
+		}
+		afterSeq = events[len(events)-1].Seq
+	}
+
+	var err error
+	if records.APIKeys, err = query.ListAllUserAPIKeys(ctx, conn.Instance, userID); err != nil {
+		return records, errors.WithMessage(err, "cannot list the API keys")
+	}
+	if records.OAuthConsents, err = query.ListUserConsents(ctx, conn.Instance, userID); err != nil {
+		return records, errors.WithMessage(err, "cannot list the OAuth2 consents")
+	}
+	return records, nil
+}
+
+// restoreUser resets deleted_at of a user deleted during the restore grace period. It fails when the username or the
+// email has been registered by another user in the meantime.
+func restoreUser(ctx context.Context, userID string) error {
+	err := conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		users, err := query.FindRestorableUsers(ctx, tx, &query.UserFilter{
+			ID: userID,
+		}, time.Now().Add(-retention.GracePeriod()))
+		if err != nil {
+			return errors.WithMessage(err, "cannot retrieve the user")
+		}
+		if len(users) == 0 {
+			return query.ErrNoRowsFound
+		}
+
+		user := users[0]
+		user.DeletedAt = query.NotDeleted
+		return query.UpdateUser(ctx, tx, user, []string{models.UserColumns.DeletedAt})
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot restore the user")
+		switch errors.Cause(err) {
+		case query.ErrNoRowsFound:
+			return ErrNotRestorable
+		case query.ErrUsernameOrEmailTaken:
+			return ErrUserAlreadyExists
+		}
+		return ErrInternalServer
+	}
+	return nil
+}
+
+// checkReuseCooldown fails with query.ErrUsernameOrEmailTaken when the username or the email belonged to a user
+// deleted during the reuse cooldown
+func checkReuseCooldown(ctx context.Context, tx *sql.Tx, username, email string) error {
+	cooldown := retention.ReuseCooldown()
+	if cooldown <= 0 {
+		return nil
+	}
+
+	reserved, err := query.IsRecentlyDeleted(ctx, tx, username, email, time.Now().Add(-cooldown))
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return errors.WithMessage(query.ErrUsernameOrEmailTaken, "reserved by a recently deleted user")
+	}
+	return nil
 }
 
 // AuthFuncOverride This will bypass on method matching allowedFunc
-func (s *Server) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
-	if fullMethodName != "/message.UserApp/CreateUser" && fullMethodName != "/message.UserApp/SignIn" {
-		return auth.Authenticator.VerifyCredentials(ctx)
+func (*Server) AuthFuncOverride(ctx context.Context, fullMethodName string) (context.Context, error) {
+	ctx = withoutIdentity(ctx)
+	if PublicMethods[fullMethodName] {
+		return ctx, nil
+	}
+
+	// the clients presenting a certificate of no service account still authenticate with a bearer token
+	ctx, verified, err := auth.Authenticator.VerifyCertificate(ctx, transport.PeerSANs(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		if ctx, err = auth.Authenticator.VerifyCredentials(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if userID, err := MDGetUserID(ctx); err == nil {
+		logging.Set(ctx, "user_id", userID)
+	}
+
+	if pendingRestore, _ := MDIsPendingRestore(ctx); pendingRestore && !pendingRestoreMethods[fullMethodName] {
+		return nil, ErrPendingRestore
+	}
+	if ctx, err = checkPrincipalType(ctx, fullMethodName); err != nil {
+		return nil, err
+	}
+	if err = checkScope(ctx, fullMethodName); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// checkPrincipalType fails when a service account calls one of the humanMethods
+func checkPrincipalType(ctx context.Context, fullMethodName string) (context.Context, error) {
+	if isServiceAccount, _ := MDIsServiceAccount(ctx); isServiceAccount && humanMethods[fullMethodName] {
+		return nil, status.Error(codes.PermissionDenied, "service accounts cannot call this method")
 	}
 	return ctx, nil
 }
+
+// withoutIdentity drops the identity the client may have put in the metadata, only the authentication sets it
+func withoutIdentity(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		md = metadata.MD{}
+	}
+	md = md.Copy()
+	for _, key := range identityKeys {
+		delete(md, key)
+	}
+	return metadata.NewIncomingContext(ctx, md)
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"github.com/gofrs/uuid"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"time"
+	"user.app/message"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/conn"
+	"user.app/pkg/constants"
+	"user.app/pkg/logging"
+	"user.app/pkg/normalize"
+	"user.app/pkg/query"
+)
+
+// serviceAccountDomain is a reserved TLD, the service accounts get an email that can never be delivered since
+// users.email is required
+const serviceAccountDomain = "service-accounts.invalid"
+
+var (
+	ErrServiceAccountNotFound = status.Error(codes.NotFound, "service account not found")
+	ErrCertificateSANTaken    = status.Error(codes.AlreadyExists, "certificate_san already taken")
+)
+
+func (*Server) CreateServiceAccount(ctx context.Context, req *message.CreateServiceAccountRequest) (*message.ServiceAccount, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	name, err := normalize.Username(req.Name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	account := &models.User{}
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		if err := checkReuseCooldown(ctx, tx, name, ""); err != nil {
+			return err
+		}
+		*account = models.User{
+			Username:      name,
+			Email:         name + "@" + serviceAccountDomain,
+			IsSuperuser:   null.BoolFrom(req.Superuser),
+			PrincipalType: constants.PrincipalService,
+		}
+		if len(req.CertificateSan) > 0 {
+			_, err := query.FindServiceAccountBySAN(ctx, tx, []string{req.CertificateSan})
+			if err == nil {
+				return ErrCertificateSANTaken
+			}
+			if err != query.ErrNoRowsFound {
+				return err
+			}
+			account.CertificateSan = null.StringFrom(req.CertificateSan)
+		}
+		return query.CreateUser(ctx, tx, account)
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot create the service account")
+		switch errors.Cause(err) {
+		case ErrCertificateSANTaken:
+			return nil, ErrCertificateSANTaken
+		case query.ErrUsernameOrEmailTaken:
+			return nil, ErrUserAlreadyExists
+		}
+		return nil, ErrInternalServer
+	}
+
+	msg, err := serviceAccountMessage(account)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the service account")
+		return nil, ErrInternalServer
+	}
+	return msg, nil
+}
+
+func (*Server) ListServiceAccounts(ctx context.Context, req *message.ListServiceAccountsRequest) (*message.ListServiceAccountsResponse, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+
+	var offset int
+	if len(req.PageToken) > 0 {
+		if offset, err = strconv.Atoi(req.PageToken); err != nil || offset < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+	}
+	limit := pageSize(req.PageSize)
+
+	accounts, err := query.ListServiceAccounts(ctx, conn.Instance, offset, limit)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot list the service accounts")
+		return nil, ErrInternalServer
+	}
+
+	res := &message.ListServiceAccountsResponse{}
+	for _, account := range accounts {
+		msg, err := serviceAccountMessage(account)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot convert the service account")
+			return nil, ErrInternalServer
+		}
+		res.ServiceAccounts = append(res.ServiceAccounts, msg)
+	}
+	if len(accounts) == limit {
+		res.NextPageToken = strconv.Itoa(offset + limit)
+	}
+	return res, nil
+}
+
+func (*Server) DeleteServiceAccount(ctx context.Context, req *message.DeleteServiceAccountRequest) (*message.Empty, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+	if _, err = uuid.FromString(req.ServiceAccountId); err != nil {
+		return nil, ErrServiceAccountNotFound
+	}
+
+	err = conn.ExecuteTx(ctx, conn.Instance, func(tx *sql.Tx) error {
+		account, err := query.FindServiceAccount(ctx, tx, req.ServiceAccountId)
+		if err != nil {
+			return err
+		}
+
+		account.DeletedAt = time.Now()
+		return query.UpdateUser(ctx, tx, account, []string{models.UserColumns.DeletedAt})
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot delete the service account")
+		if errors.Cause(err) == query.ErrNoRowsFound {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, ErrInternalServer
+	}
+	return &message.Empty{}, nil
+}
+
+func (*Server) CreateServiceAccountAPIKey(ctx context.Context, req *message.CreateServiceAccountAPIKeyRequest) (*message.CreateAPIKeyResponse, error) {
+	account, err := findServiceAccount(ctx, req.ServiceAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := uniqueScopes(req.Scopes)
+	if hasScope(scopes, auth.ScopeAdmin) && !account.IsSuperuser.Bool {
+		return nil, status.Error(codes.FailedPrecondition, "the admin scope can only be granted to a superuser service account")
+	}
+	return createAPIKey(ctx, account.ID, callerID(ctx), req.Name, scopes, req.ExpiresAt)
+}
+
+func (*Server) ListServiceAccountAPIKeys(ctx context.Context, req *message.ListServiceAccountAPIKeysRequest) (*message.ListAPIKeysResponse, error) {
+	account, err := findServiceAccount(ctx, req.ServiceAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAPIKeys(ctx, account.ID)
+}
+
+func (*Server) RevokeServiceAccountAPIKey(ctx context.Context, req *message.RevokeServiceAccountAPIKeyRequest) (*message.Empty, error) {
+	account, err := findServiceAccount(ctx, req.ServiceAccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	return revokeAPIKey(ctx, account.ID, callerID(ctx), req.KeyId)
+}
+
+// findServiceAccount returns the service account whose API keys a superuser manages
+func findServiceAccount(ctx context.Context, id string) (*models.User, error) {
+	isSuperUser, err := MDIsSuperUser(ctx)
+	if err != nil || !isSuperUser {
+		return nil, ErrPermissionDenied
+	}
+	if _, err = uuid.FromString(id); err != nil {
+		return nil, ErrServiceAccountNotFound
+	}
+
+	account, err := query.FindServiceAccount(ctx, conn.Instance, id)
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return nil, ErrServiceAccountNotFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the service account")
+		return nil, ErrInternalServer
+	}
+	return account, nil
+}
+
+// callerID returns the ID of the calling user or service account, empty for the service accounts of the tls
+// configuration which have none
+func callerID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(constants.MDKeyUserID); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// serviceAccountMessage converts the service account to its message
+func serviceAccountMessage(account *models.User) (*message.ServiceAccount, error) {
+	createdAt, err := ptypes.TimestampProto(account.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &message.ServiceAccount{
+		Id:             account.ID,
+		Name:           account.Username,
+		Superuser:      account.IsSuperuser.Bool,
+		CertificateSan: account.CertificateSan.String,
+		CreatedAt:      createdAt,
+	}, nil
+}
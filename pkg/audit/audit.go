@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"google.golang.org/grpc/metadata"
+	"hash"
+	"strconv"
+	"time"
+	"user.app/models"
+	"user.app/pkg/constants"
+	"user.app/pkg/events"
+	"user.app/pkg/query"
+	"user.app/pkg/transport"
+)
+
+const (
+	// verifyBatchSize is the number of events read at once by Verify
+	verifyBatchSize = 1000
+	// maxRequestIDLength is the length of audit_events.request_id, longer IDs are truncated
+	maxRequestIDLength = 100
+)
+
+// genesisHash is the prev_hash of the first event of the chain
+var genesisHash = make([]byte, sha256.Size)
+
+var ErrChainBroken = errors.New("audit chain broken")
+
+// Event is a mutation to record. ChangedFields holds the names of the changed fields, never their values, so that
+// secrets like the password hash cannot end up in the log.
+type Event struct {
+	// ActorID is the user who made the change, empty for the system jobs
+	ActorID       string
+	TargetUserID  string
+	Action        string
+	ChangedFields []string
+}
+
+// audited are the events recorded in the audit log, the sign ins are not mutations made through the API
+var audited = []events.Type{
+	events.UserCreated,
+	events.UserUpdated,
+	events.UserDeleted,
+	events.UserRestored,
+	events.UserErased,
+	events.PasswordChanged,
+	events.APIKeyCreated,
+	events.APIKeyRevoked,
+}
+
+// Subscribe records the audited events published on the bus, in the transaction of the mutation
+func Subscribe(bus *events.Bus) {
+	bus.Subscribe(func(ctx context.Context, exec boil.ContextExecutor, event events.Event) error {
+		return Record(ctx, exec, Event{
+			ActorID:       event.ActorID,
+			TargetUserID:  event.UserID,
+			Action:        string(event.Type),
+			ChangedFields: event.ChangedColumns,
+		})
+	}, audited...)
+}
+
+// Record appends the event to the audit chain using the caller's transaction, so that the event is only written when
+// the mutation is. The request ID and the client IP are taken from the gRPC context when available.
+func Record(ctx context.Context, exec boil.ContextExecutor, event Event) error {
+	last, err := query.LastAuditEvent(ctx, exec)
+	if err != nil {
+		return errors.WithMessage(err, "cannot retrieve the head of the audit chain")
+	}
+
+	row := &models.AuditEvent{
+		Seq:           1,
+		Action:        event.Action,
+		ChangedFields: types.StringArray(event.ChangedFields),
+		RequestID:     requestID(ctx),
+		ClientIP:      transport.ClientIP(ctx),
+		// CockroachDB stores microseconds, the hash has to be computed on what is read back
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		PrevHash:  genesisHash,
+	}
+	if row.ChangedFields == nil {
+		row.ChangedFields = types.StringArray{}
+	}
+	if last != nil {
+		row.Seq = last.Seq + 1
+		row.PrevHash = last.Hash
+	}
+	if len(event.ActorID) > 0 {
+		row.ActorID = null.StringFrom(event.ActorID)
+	}
+	if len(event.TargetUserID) > 0 {
+		row.TargetUserID = null.StringFrom(event.TargetUserID)
+	}
+	row.Hash = Hash(row.PrevHash, row)
+
+	if err = query.CreateAuditEvent(ctx, exec, row); err != nil {
+		return errors.WithMessage(err, "cannot record the audit event")
+	}
+	return nil
+}
+
+// Hash chains the event to the previous hash. Every field is length prefixed so that moving bytes from one field to
+// the next changes the hash.
+func Hash(prevHash []byte, event *models.AuditEvent) []byte {
+	h := sha256.New()
+	h.Write(prevHash)
+	writeField(h, strconv.FormatInt(event.Seq, 10))
+	writeField(h, event.ActorID.String)
+	writeField(h, event.TargetUserID.String)
+	writeField(h, event.Action)
+	writeField(h, strconv.Itoa(len(event.ChangedFields)))
+	for _, field := range event.ChangedFields {
+		writeField(h, field)
+	}
+	writeField(h, event.RequestID)
+	writeField(h, event.ClientIP)
+	writeField(h, event.CreatedAt.UTC().Format(time.RFC3339Nano))
+	return h.Sum(nil)
+}
+
+// Verify walks the whole chain and returns the number of events checked. It fails with ErrChainBroken on the first
+// event that has been modified, removed or inserted out of band.
+func Verify(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	c := newChain()
+	for {
+		events, err := query.ListAuditEvents(ctx, exec, nil, c.lastSeq, verifyBatchSize)
+		if err != nil {
+			return c.checked, errors.WithMessage(err, "cannot list the audit events")
+		}
+
+		for _, event := range events {
+			if err = c.check(event); err != nil {
+				return c.checked, err
+			}
+		}
+
+		if len(events) < verifyBatchSize {
+			return c.checked, nil
+		}
+	}
+}
+
+// chain checks the events in seq order against the ones before them
+type chain struct {
+	checked  int64
+	lastSeq  int64
+	prevHash []byte
+}
+
+func newChain() *chain {
+	return &chain{prevHash: genesisHash}
+}
+
+// check fails with ErrChainBroken when the event does not follow the last checked one
+func (c *chain) check(event *models.AuditEvent) error {
+	if event.Seq != c.lastSeq+1 {
+		return errors.Wrapf(ErrChainBroken, "event %d is missing", c.lastSeq+1)
+	}
+	if !bytes.Equal(event.PrevHash, c.prevHash) {
+		return errors.Wrapf(ErrChainBroken, "event %d is not chained to event %d", event.Seq, c.lastSeq)
+	}
+	if !bytes.Equal(event.Hash, Hash(c.prevHash, event)) {
+		return errors.Wrapf(ErrChainBroken, "event %d has been modified", event.Seq)
+	}
+
+	c.checked++
+	c.lastSeq = event.Seq
+	c.prevHash = event.Hash
+	return nil
+}
+
+func writeField(h hash.Hash, value string) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(value)))
+	h.Write(length[:])
+	h.Write([]byte(value))
+}
+
+// requestID returns the ID the caller assigned to the request, if any
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constants.MDKeyRequestID); len(values) > 0 {
+			if len(values[0]) > maxRequestIDLength {
+				return values[0][:maxRequestIDLength]
+			}
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/types"
+	"testing"
+	"time"
+	"user.app/models"
+)
+
+// buildChain returns n events chained like Record chains them
+func buildChain(n int) []*models.AuditEvent {
+	events := make([]*models.AuditEvent, 0, n)
+	prevHash := genesisHash
+	for i := 1; i <= n; i++ {
+		event := &models.AuditEvent{
+			Seq:           int64(i),
+			ActorID:       null.StringFrom("a0000000-0000-0000-0000-000000000001"),
+			TargetUserID:  null.StringFrom("b0000000-0000-0000-0000-000000000002"),
+			Action:        "user.updated",
+			ChangedFields: types.StringArray{"email"},
+			RequestID:     "req-1",
+			ClientIP:      "192.0.2.1",
+			CreatedAt:     time.Date(2020, 9, 5, 12, 0, i, 1000, time.UTC),
+			PrevHash:      prevHash,
+		}
+		event.Hash = Hash(prevHash, event)
+		prevHash = event.Hash
+		events = append(events, event)
+	}
+	return events
+}
+
+// verify checks the events like Verify checks the rows
+func verify(events []*models.AuditEvent) (int64, error) {
+	c := newChain()
+	for _, event := range events {
+		if err := c.check(event); err != nil {
+			return c.checked, err
+		}
+	}
+	return c.checked, nil
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(events []*models.AuditEvent) []*models.AuditEvent
+		checked int64
+	}{
+		{
+			name:    "intact",
+			tamper:  func(events []*models.AuditEvent) []*models.AuditEvent { return events },
+			checked: 5,
+		},
+		{
+			name: "action modified",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[2].Action = "user.deleted"
+				return events
+			},
+			checked: 2,
+		},
+		{
+			name: "changed field added",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[1].ChangedFields = append(events[1].ChangedFields, "password")
+				return events
+			},
+			checked: 1,
+		},
+		{
+			name: "bytes moved between fields",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[3].RequestID, events[3].ClientIP = "req-1192.0.2.1", ""
+				return events
+			},
+			checked: 3,
+		},
+		{
+			name: "timestamp modified",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[4].CreatedAt = events[4].CreatedAt.Add(time.Microsecond)
+				return events
+			},
+			checked: 4,
+		},
+		{
+			name: "rows reordered",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[1].Seq, events[2].Seq = events[2].Seq, events[1].Seq
+				events[1], events[2] = events[2], events[1]
+				return events
+			},
+			checked: 1,
+		},
+		{
+			name: "row removed",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				return append(events[:2], events[3:]...)
+			},
+			checked: 2,
+		},
+		{
+			name: "row removed and renumbered",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events = append(events[:2], events[3:]...)
+				for i, event := range events {
+					event.Seq = int64(i + 1)
+				}
+				return events
+			},
+			checked: 2,
+		},
+		{
+			name: "row rehashed",
+			tamper: func(events []*models.AuditEvent) []*models.AuditEvent {
+				events[2].TargetUserID = null.StringFrom("c0000000-0000-0000-0000-000000000003")
+				events[2].Hash = Hash(events[2].PrevHash, events[2])
+				return events
+			},
+			checked: 3,
+		},
+	}
+	for _, tt := range tests {
+		checked, err := verify(tt.tamper(buildChain(5)))
+		if tt.checked == 5 {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+		} else if errors.Cause(err) != ErrChainBroken {
+			t.Errorf("%s: got %v, want ErrChainBroken", tt.name, err)
+		}
+		if checked != tt.checked {
+			t.Errorf("%s: %d events checked, want %d", tt.name, checked, tt.checked)
+		}
+	}
+}
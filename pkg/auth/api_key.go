@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+	"user.app/pkg/conn"
+	"user.app/pkg/constants"
+	"user.app/pkg/logging"
+	"user.app/pkg/query"
+)
+
+const (
+	// APIKeyPrefix starts every API key, it tells them apart from the JWTs and lets the secret scanners find them
+	APIKeyPrefix = "uak_"
+
+	// ScopeRead and the other scopes are granted to the API keys and the OAuth2 clients, see api.methodScopes for the
+	// methods they allow
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+
+	// apiKeyIDBytes is the randomness of the visible part of a key, it is looked up in api_keys.prefix
+	apiKeyIDBytes = 6
+	// apiKeySecretBytes is the randomness of the secret part of a key
+	apiKeySecretBytes = 32
+	// lastUsedResolution is how stale api_keys.last_used_at can be, it is not written on every call
+	lastUsedResolution = time.Minute
+)
+
+// Scopes are the scopes an API key can be granted
+var Scopes = []string{ScopeRead, ScopeWrite, ScopeAdmin}
+
+// GenerateAPIKey returns a new API key, its prefix and its hash. The key is uak_<prefix>_<secret>, it is only shown
+// once and only its sha256 is stored: the secret is random enough for a fast hash, argon2 would be paid on every call.
+func GenerateAPIKey() (key, prefix string, hash []byte, err error) {
+	id := make([]byte, apiKeyIDBytes)
+	if _, err = rand.Read(id); err != nil {
+		return "", "", nil, errors.Wrap(err, "cannot generate the API key prefix")
+	}
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", nil, errors.Wrap(err, "cannot generate the API key secret")
+	}
+
+	prefix = APIKeyPrefix + hex.EncodeToString(id)
+	key = prefix + "_" + hex.EncodeToString(secret)
+	return key, prefix, HashAPIKey(key), nil
+}
+
+// HashAPIKey returns the hash of the API key stored in api_keys.hash
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// verifyAPIKey authenticates the call as the owner of the API key, a human or a service account. The owner has to be
+// active, and the call gets the superuser flag of the owner, the scopes of the key are enforced by the caller of
+// VerifyCredentials.
+func (j *JWT) verifyAPIKey(grpcCtx context.Context, key string) (context.Context, error) {
+	separator := strings.LastIndex(key, "_")
+	if separator <= len(APIKeyPrefix) {
+		return nil, ErrInvalidCredentialsError
+	}
+
+	apiKey, err := query.FindAPIKeyByPrefix(grpcCtx, conn.Instance, key[:separator])
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return nil, ErrInvalidCredentialsError
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare(HashAPIKey(key), apiKey.Hash) != 1 {
+		return nil, ErrInvalidCredentialsError
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt.Valid || (apiKey.ExpiresAt.Valid && !apiKey.ExpiresAt.Time.After(now)) {
+		return nil, ErrInvalidCredentialsError
+	}
+
+	user, err := query.FindUser(grpcCtx, conn.Instance, &query.UserFilter{
+		ID: apiKey.UserID,
+	})
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			// the owner is deleted, its keys come back if it is restored
+			return nil, ErrInvalidCredentialsError
+		}
+		return nil, err
+	}
+
+	if !apiKey.LastUsedAt.Valid || now.Sub(apiKey.LastUsedAt.Time) >= lastUsedResolution {
+		if err = query.TouchAPIKey(grpcCtx, conn.Instance, apiKey, now); err != nil {
+			logging.Ctx(grpcCtx).Error().Err(err).Msg("cannot record the last use of the API key")
+		}
+	}
+
+	ctx, md := withPrincipal(grpcCtx, user)
+	md.Set(constants.MDKeyAPIKeyID, apiKey.ID)
+	md.Set(constants.MDKeyScopes, apiKey.Scopes...)
+	return ctx, nil
+}
@@ -5,24 +5,37 @@ import (
 	"github.com/dgrijalva/jwt-go"
 	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
 	"github.com/pkg/errors"
-	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/hlandau/passlib.v1"
 	"gopkg.in/hlandau/passlib.v1/abstract"
 	"gopkg.in/hlandau/passlib.v1/hash/argon2"
 	"strconv"
+	"strings"
 	"time"
 	"user.app/message"
 	"user.app/models"
 	"user.app/pkg/conn"
 	"user.app/pkg/constants"
+	"user.app/pkg/events"
+	"user.app/pkg/logging"
+	"user.app/pkg/metrics"
 	"user.app/pkg/query"
+	"user.app/pkg/retention"
+	"user.app/pkg/tracing"
 )
 
 var (
 	ErrInvalidCredentialsError = errors.New("Invalid credentials")
-	PasslibCtx                 = passlib.Context{
+	// errUnknownUser and errBadPassword tell the failed sign ins apart for the metrics, the clients only get
+	// ErrInvalidCredentialsError
+	errUnknownUser = errors.New("unknown user")
+	errBadPassword = errors.New("bad password")
+	// errServiceAccount is returned when a service account tries to sign in with a password
+	errServiceAccount = errors.New("service accounts cannot sign in with a password")
+	PasslibCtx        = passlib.Context{
 		Schemes: []abstract.Scheme{
 			argon2.New(1, 32*1024, 4),
 		},
@@ -62,9 +75,10 @@ type (
 
 	// UserSessionDetail holds the session details for a user
 	UserSessionDetail struct {
-		User      *models.User
-		ClaimType ClaimType
-		SessionID string
+		User           *models.User
+		ClaimType      ClaimType
+		SessionID      string
+		PendingRestore bool
 	}
 )
 
@@ -73,6 +87,10 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return grpc_auth.UnaryServerInterceptor(nil)
 }
 
+// StreamServerInterceptor registers as a stream server interceptor
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return grpc_auth.StreamServerInterceptor(nil)
+}
 
 // NewJWTAuth is the constructor for the JWT
 func NewJWTAuth() (*JWT, error) {
@@ -90,7 +108,12 @@ func NewJWTAuth() (*JWT, error) {
 
 // EncodeToken creates a JWT token for a user argument for subsequent call verification.
 func (j *JWT) EncodeToken(user *models.User, claimType ClaimType, sessionID string) (*Token, error) {
-	claims := j.prepareClaims(user, claimType, sessionID)
+	return j.encodeToken(user, claimType, sessionID, time.Duration(DefaultLifetime)*time.Hour)
+}
+
+// encodeToken creates a JWT token for a user which expires after the lifetime
+func (j *JWT) encodeToken(user *models.User, claimType ClaimType, sessionID string, lifetime time.Duration) (*Token, error) {
+	claims := j.prepareClaims(user, claimType, sessionID, lifetime)
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	accessToken, err := token.SignedString([]byte(SigningKey))
@@ -105,10 +128,9 @@ func (j *JWT) EncodeToken(user *models.User, claimType ClaimType, sessionID stri
 }
 
 // prepareClaims creates a UserClaim object with expiry time
-func (j *JWT) prepareClaims(user *models.User, claimType ClaimType, sessionID string) UserClaims {
+func (j *JWT) prepareClaims(user *models.User, claimType ClaimType, sessionID string, lifetime time.Duration) UserClaims {
 	currentTime := time.Now()
-	jwtLifeTimeInHours := DefaultLifetime
-	expireTime := currentTime.Add(time.Hour * time.Duration(jwtLifeTimeInHours)).Unix()
+	expireTime := currentTime.Add(lifetime).Unix()
 
 	// Create the UserClaims
 	claims := UserClaims{
@@ -118,6 +140,7 @@ func (j *JWT) prepareClaims(user *models.User, claimType ClaimType, sessionID st
 		SessionID: sessionID,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expireTime,
+			IssuedAt:  currentTime.Unix(),
 			Issuer:    "bob.dylan",
 		},
 	}
@@ -144,8 +167,16 @@ func (j *JWT) decodeJWT(tokenString string) (*UserClaims, error) {
 }
 
 // VerifyCredentials called on each and every request made.
-// It decodes the JWT token and puts user_id, name and email as principal in the context.
+// It decodes the JWT token, or verifies the API key, and puts user_id, name and email as principal in the context.
 func (j *JWT) VerifyCredentials(grpcCtx context.Context) (context.Context, error) {
+	// the returned context does not carry the span, the handler is not part of the verification
+	_, span := tracing.Start(grpcCtx, "auth.VerifyCredentials")
+	ctx, err := j.verifyCredentials(grpcCtx)
+	tracing.End(span, err)
+	return ctx, err
+}
+
+func (j *JWT) verifyCredentials(grpcCtx context.Context) (context.Context, error) {
 	var (
 		err         error
 		tokenString string
@@ -156,6 +187,9 @@ func (j *JWT) VerifyCredentials(grpcCtx context.Context) (context.Context, error
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to extract token from request header")
 	}
+	if strings.HasPrefix(tokenString, APIKeyPrefix) {
+		return j.verifyAPIKey(grpcCtx, tokenString)
+	}
 
 	userClaims, err = j.decodeJWT(tokenString)
 	if err != nil {
@@ -167,17 +201,111 @@ func (j *JWT) VerifyCredentials(grpcCtx context.Context) (context.Context, error
 		return nil, err
 	}
 
-	if session.ClaimType != Internal {
-		// handle other ways of authentication
+	if session.ClaimType == Login {
+		// the cookie of the login page is not a bearer token
+		return nil, ErrInvalidCredentialsError
 	}
 
 	md, _ := metadata.FromIncomingContext(grpcCtx)
 	md.Set(constants.MDKeyUserID, userClaims.ID)
 	md.Set(constants.MDKeyUsername, userClaims.Username)
 	md.Set(constants.MDKeySuperUser, strconv.FormatBool(session.SuperUser))
+	md.Set(constants.MDKeyPrincipalType, session.PrincipalType)
+	md.Set(constants.MDKeyPendingRestore, strconv.FormatBool(session.PendingRestore))
+	if len(session.ClientID) > 0 {
+		// the scopes of the client are enforced by the caller of VerifyCredentials, like the ones of the API keys
+		md.Set(constants.MDKeyClientID, session.ClientID)
+		md.Set(constants.MDKeyScopes, session.Scopes...)
+	}
 	return metadata.NewIncomingContext(grpcCtx, md), nil
 }
 
+// withPrincipal puts the user authenticated without a session as principal in the context
+func withPrincipal(ctx context.Context, user *models.User) (context.Context, metadata.MD) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md.Set(constants.MDKeyUserID, user.ID)
+	md.Set(constants.MDKeyUsername, user.Username)
+	md.Set(constants.MDKeySuperUser, strconv.FormatBool(user.IsSuperuser.Bool))
+	md.Set(constants.MDKeyPrincipalType, user.PrincipalType)
+	md.Set(constants.MDKeyPendingRestore, strconv.FormatBool(false))
+	return metadata.NewIncomingContext(ctx, md), md
+}
+
+// ListUserSessions returns the sessions of a user present in the session registry
+func (j *JWT) ListUserSessions(ctx context.Context, userID string) ([]Session, error) {
+	return j.registry.ListUserSessions(ctx, userID)
+}
+
+// CountSessions returns the number of active sessions in the session registry
+func (j *JWT) CountSessions(ctx context.Context) (int, error) {
+	return j.registry.CountSessions(ctx)
+}
+
+// HashPassword hashes the password with argon2 in its own span, the hashing dominates the latency of the calls
+func HashPassword(ctx context.Context, password string) (string, error) {
+	_, span := tracing.Start(ctx, "argon2.Hash")
+	hash, err := PasslibCtx.Hash(password)
+	tracing.End(span, err)
+	return hash, err
+}
+
+// VerifyPassword checks the password against its argon2 hash in its own span
+func VerifyPassword(ctx context.Context, password, hash string) error {
+	_, span := tracing.Start(ctx, "argon2.Verify")
+	err := PasslibCtx.VerifyNoUpgrade(password, hash)
+	span.End()
+	return err
+}
+
+// Ping checks the backend of the session registry
+func (j *JWT) Ping(ctx context.Context) error {
+	return j.registry.Ping(ctx)
+}
+
+// EvictUserSessions signs the user out of all its sessions
+func (j *JWT) EvictUserSessions(ctx context.Context, userID string) error {
+	return j.registry.EvictUserSessions(ctx, userID)
+}
+
+// Subscribe evicts the cached sessions of the users that are deleted, erased or whose password is changed, and of the
+// users whose privileges cached in the sessions are updated. The eviction happens before the commit, a rolled back
+// mutation only costs the user a new sign in.
+func (j *JWT) Subscribe(bus *events.Bus) {
+	bus.Subscribe(func(ctx context.Context, _ boil.ContextExecutor, event events.Event) error {
+		return j.registry.EvictUserSessions(ctx, event.UserID)
+	}, events.UserDeleted, events.UserErased, events.PasswordChanged)
+	bus.Subscribe(func(ctx context.Context, _ boil.ContextExecutor, event events.Event) error {
+		for _, column := range event.ChangedColumns {
+			if column == models.UserColumns.IsSuperuser || column == models.UserColumns.PrincipalType {
+				return j.registry.EvictUserSessions(ctx, event.UserID)
+			}
+		}
+		return nil
+	}, events.UserUpdated)
+}
+
+// CompleteRestore clears the pending restore flag of the current session once the account has been restored
+func (j *JWT) CompleteRestore(ctx context.Context) error {
+	tokenString, err := grpc_auth.AuthFromMD(ctx, "bearer")
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to extract token from request header")
+		return ErrInvalidCredentialsError
+	}
+
+	userClaims, err := j.decodeJWT(tokenString)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to decode JWT")
+		return ErrInvalidCredentialsError
+	}
+
+	session, err := j.registry.GetSessionRegistry(ctx, *userClaims)
+	if err != nil {
+		return err
+	}
+	session.PendingRestore = false
+	return j.registry.UpdateSessionRegistry(ctx, session)
+}
+
 // InvalidateSession evicts the current session from the session registry
 func (j *JWT) InvalidateSession(ctx context.Context) error {
 	var (
@@ -188,13 +316,13 @@ func (j *JWT) InvalidateSession(ctx context.Context) error {
 
 	tokenString, err = grpc_auth.AuthFromMD(ctx, "bearer")
 	if err != nil {
-		log.Error().Err(err).Msg("unable to extract token from request header")
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to extract token from request header")
 		return ErrInvalidCredentialsError
 	}
 
 	userClaims, err = j.decodeJWT(tokenString)
 	if err != nil {
-		log.Error().Err(err).Msg("unable to decode JWT")
+		logging.Ctx(ctx).Error().Err(err).Msg("unable to decode JWT")
 		return ErrInvalidCredentialsError
 	}
 
@@ -202,30 +330,76 @@ func (j *JWT) InvalidateSession(ctx context.Context) error {
 }
 
 // Authenticate creates a session for a user whose authentication request is present in the parameter
-// the authentication process is delegated to function handleUsernamePasswordAuthentication
+// the authentication process is delegated to function handleUsernamePasswordAuthentication. A deleted user still in
+// its restore grace period gets a session which is pending restore.
 func (j *JWT) Authenticate(ctx context.Context, req *message.AuthRequest) (UserSessionDetail, error) {
+	return j.authenticate(ctx, req, Internal)
+}
+
+// authenticate creates a session of the claim type for the user
+func (j *JWT) authenticate(ctx context.Context, req *message.AuthRequest, claimType ClaimType) (UserSessionDetail, error) {
 	var user *models.User
 	var err error
 	var sessionObj Session
 
 	user, err = j.handleUsernamePasswordAuthentication(ctx, req.Username, req.Password)
-	if err != nil {
+	if err == errUnknownUser || err == errBadPassword {
+		notFound := err
+		user, err = j.handleRestorableUserAuthentication(ctx, req.Username, req.Password)
+		if err == errUnknownUser {
+			// there is no restorable user either, the reason is the one of the active user
+			err = notFound
+		}
+		sessionObj.PendingRestore = user != nil
+	}
+	switch err {
+	case nil:
+	case errServiceAccount:
+		metrics.SignIn(metrics.SignInServiceAccount)
+		return UserSessionDetail{}, ErrInvalidCredentialsError
+	case errUnknownUser:
+		metrics.SignIn(metrics.SignInUnknownUser)
+		return UserSessionDetail{}, ErrInvalidCredentialsError
+	case errBadPassword:
+		metrics.SignIn(metrics.SignInBadPassword)
+		return UserSessionDetail{}, ErrInvalidCredentialsError
+	default:
+		metrics.SignIn(metrics.SignInError)
 		return UserSessionDetail{}, err
 	}
-	sessionObj.ClaimType = Internal
+
+	// the hooks are skipped, the last login is not an update made by the user, UserSignedIn is published instead
+	user.LastLogin = null.TimeFrom(time.Now())
+	if err = query.UpdateUser(boil.SkipHooks(ctx), conn.Instance, user, []string{models.UserColumns.LastLogin}); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot update the last login")
+	}
+	err = events.DefaultBus.Publish(ctx, conn.Instance, events.Event{
+		Type:    events.UserSignedIn,
+		UserID:  user.ID,
+		ActorID: user.ID,
+	})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot publish the sign in")
+	}
+	sessionObj.ClaimType = claimType
 	sessionObj.UserID = user.ID
+	// only the humans sign in with a password
+	sessionObj.PrincipalType = constants.PrincipalHuman
 	if user.IsSuperuser.Bool {
 		sessionObj.SuperUser = true
 	}
 
 	sessionID, err := j.registry.AddToSessionRegistry(ctx, sessionObj)
 	if err != nil {
+		metrics.SignIn(metrics.SignInError)
 		return UserSessionDetail{}, err
 	}
+	metrics.SignIn(metrics.SignInSuccess)
 	return UserSessionDetail{
-		User:      user,
-		ClaimType: sessionObj.ClaimType,
-		SessionID: sessionID,
+		User:           user,
+		ClaimType:      sessionObj.ClaimType,
+		SessionID:      sessionID,
+		PendingRestore: sessionObj.PendingRestore,
 	}, nil
 }
 
@@ -238,14 +412,43 @@ func (j *JWT) handleUsernamePasswordAuthentication(ctx context.Context, username
 	if err != nil {
 		switch err {
 		case query.ErrNoRowsFound:
-			return nil, ErrInvalidCredentialsError
+			return nil, errUnknownUser
 		}
 		return nil, err
 	}
+	if user.PrincipalType == constants.PrincipalService {
+		logging.Ctx(ctx).Error().Msg("service accounts cannot sign in with a password")
+		return nil, errServiceAccount
+	}
 
-	if err = PasslibCtx.VerifyNoUpgrade(password, user.Password); err != nil {
-		log.Error().Err(err).Msg("supplied password does not match stored password")
-		return nil, ErrInvalidCredentialsError
+	if err = VerifyPassword(ctx, password, user.Password); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("supplied password does not match stored password")
+		return nil, errBadPassword
 	}
 	return user, nil
 }
+
+// handleRestorableUserAuthentication authenticates against the users deleted during the restore grace period. The
+// username may have been registered again after the reuse cooldown, so every restorable user having it is tried.
+func (j *JWT) handleRestorableUserAuthentication(ctx context.Context, username, password string) (*models.User, error) {
+	users, err := query.FindRestorableUsers(ctx, conn.Instance, &query.UserFilter{
+		Username: username,
+	}, time.Now().Add(-retention.GracePeriod()))
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, errUnknownUser
+	}
+
+	for _, user := range users {
+		if user.PrincipalType == constants.PrincipalService {
+			continue
+		}
+		if err = VerifyPassword(ctx, password, user.Password); err == nil {
+			return user, nil
+		}
+	}
+	logging.Ctx(ctx).Error().Msg("supplied password does not match any restorable user")
+	return nil, errBadPassword
+}
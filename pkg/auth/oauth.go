@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"time"
+	"user.app/message"
+	"user.app/models"
+)
+
+const (
+	// OAuth2 is the claim type of the access tokens issued by the OAuth2 token endpoint
+	OAuth2 ClaimType = "OAuth2"
+	// Login is the claim type of the sessions of the login page of the OpenID Connect provider, their token is kept in
+	// a cookie and is only accepted by the authorization endpoint
+	Login ClaimType = "Login"
+)
+
+// ErrLoginPendingRestore is returned when a user pending restore signs in on the login page, the account has to be
+// restored through the API first
+var ErrLoginPendingRestore = errors.New("the account is pending restore")
+
+// IssueAccessToken creates a session of the OAuth2 client for the user, a service account for the client credentials,
+// and returns its access token which expires after the lifetime. The session is evicted with the ones of the sign ins
+// when the user is deleted.
+func (j *JWT) IssueAccessToken(ctx context.Context, user *models.User, clientID string, scopes []string,
+	lifetime time.Duration) (*Token, Session, error) {
+	session := Session{
+		UserID:        user.ID,
+		ClaimType:     OAuth2,
+		SuperUser:     user.IsSuperuser.Bool,
+		PrincipalType: user.PrincipalType,
+		ClientID:      clientID,
+		Scopes:        scopes,
+	}
+	sessionID, err := j.registry.AddToSessionRegistry(ctx, session)
+	if err != nil {
+		return nil, Session{}, err
+	}
+	session.ID = sessionID
+
+	token, err := j.encodeToken(user, OAuth2, sessionID, lifetime)
+	if err != nil {
+		if evictErr := j.EvictSession(ctx, sessionID); evictErr != nil {
+			return nil, Session{}, errors.WithMessage(evictErr, err.Error())
+		}
+		return nil, Session{}, err
+	}
+	return token, session, nil
+}
+
+// IntrospectToken returns the claims and the session of an access token, or of the JWT of a sign in, that is still
+// active. ErrInvalidCredentialsError is returned once it has expired or its session has been evicted, and for the
+// tokens of the login cookies.
+func (j *JWT) IntrospectToken(ctx context.Context, tokenString string) (*UserClaims, Session, error) {
+	userClaims, err := j.decodeJWT(tokenString)
+	if err != nil {
+		return nil, Session{}, ErrInvalidCredentialsError
+	}
+
+	session, err := j.registry.GetSessionRegistry(ctx, *userClaims)
+	if err != nil {
+		return nil, Session{}, err
+	}
+	if session.ClaimType == Login {
+		return nil, Session{}, ErrInvalidCredentialsError
+	}
+	return userClaims, session, nil
+}
+
+// AuthenticateLogin signs the user in on the login page and returns the token of the cookie of its session
+func (j *JWT) AuthenticateLogin(ctx context.Context, username, password string) (*models.User, *Token, error) {
+	detail, err := j.authenticate(ctx, &message.AuthRequest{Username: username, Password: password}, Login)
+	if err != nil {
+		return nil, nil, err
+	}
+	if detail.PendingRestore {
+		if err = j.EvictSession(ctx, detail.SessionID); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrLoginPendingRestore
+	}
+
+	token, err := j.EncodeToken(detail.User, Login, detail.SessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return detail.User, token, nil
+}
+
+// VerifyLogin returns the claims and the session of the token of the login cookie, ErrInvalidCredentialsError once it
+// has expired or its session has been evicted
+func (j *JWT) VerifyLogin(ctx context.Context, tokenString string) (*UserClaims, Session, error) {
+	userClaims, err := j.decodeJWT(tokenString)
+	if err != nil {
+		return nil, Session{}, ErrInvalidCredentialsError
+	}
+
+	session, err := j.registry.GetSessionRegistry(ctx, *userClaims)
+	if err != nil {
+		return nil, Session{}, err
+	}
+	if session.ClaimType != Login {
+		return nil, Session{}, ErrInvalidCredentialsError
+	}
+	return userClaims, session, nil
+}
+
+// EvictSession removes the session from the session registry, its access token cannot be used anymore
+func (j *JWT) EvictSession(ctx context.Context, sessionID string) error {
+	return j.registry.EvictFromSessionRegistry(ctx, UserClaims{SessionID: sessionID})
+}
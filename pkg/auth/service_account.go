@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"context"
+	"user.app/pkg/conn"
+	"user.app/pkg/query"
+)
+
+// VerifyCertificate authenticates the call as the service account having one of the SANs of the verified client
+// certificate, it returns false when there is none and the call has to present a bearer token instead
+func (j *JWT) VerifyCertificate(ctx context.Context, sans []string) (context.Context, bool, error) {
+	if len(sans) == 0 {
+		return ctx, false, nil
+	}
+
+	account, err := query.FindServiceAccountBySAN(ctx, conn.Instance, sans)
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return ctx, false, nil
+		}
+		return nil, false, err
+	}
+
+	ctx, _ = withPrincipal(ctx, account)
+	return ctx, true, nil
+}
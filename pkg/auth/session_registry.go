@@ -18,6 +18,15 @@ type (
 		UserID    string
 		ClaimType ClaimType
 		SuperUser bool
+		// PrincipalType is the users.principal_type of the user, only the OAuth2 clients get sessions for the service
+		// accounts
+		PrincipalType string
+		// ClientID and Scopes are set for the sessions of the access tokens issued to an OAuth2 client
+		ClientID string
+		Scopes   []string
+		// PendingRestore is set for the sessions of deleted users that signed in during their restore grace period
+		PendingRestore bool
+		CreatedAt      time.Time
 	}
 
 	// SessionRegistry handles the entire session's life cycle
@@ -34,25 +43,40 @@ type (
 
 		// EvictFromSessionRegistry removes a session from the session registry
 		EvictFromSessionRegistry(ctx context.Context, claim UserClaims) error
+
+		// ListUserSessions returns all the sessions of a user
+		ListUserSessions(ctx context.Context, userID string) ([]Session, error)
+
+		// EvictUserSessions removes all the sessions of a user from the session registry
+		EvictUserSessions(ctx context.Context, userID string) error
+
+		// CountSessions returns the number of sessions that have not expired
+		CountSessions(ctx context.Context) (int, error)
+
+		// Ping returns an error when the backend of the session registry cannot be reached
+		Ping(ctx context.Context) error
 	}
 
 	// InMemSessionRegistry is an implementation of SessionRegistry that uses In memory cache, BigCache, to handle the
 	// sessions
 	InMemSessionRegistry struct {
 		sessionRegistry *bigcache.BigCache
+		lifeTime        time.Duration
 	}
 )
 
 // NewInMemSessionRegistry is the constructor for the InMemorySessionRegistry
 func NewInMemSessionRegistry(jwtLifeTimeInHours int) (SessionRegistry, error) {
+	lifeTime := time.Duration(jwtLifeTimeInHours) * time.Hour
 	sessionRegistry, err := bigcache.NewBigCache(
-		bigcache.DefaultConfig(time.Duration(jwtLifeTimeInHours) * time.Hour),
+		bigcache.DefaultConfig(lifeTime),
 	)
 	if err != nil {
 		return nil, err
 	}
 	return &InMemSessionRegistry{
 		sessionRegistry: sessionRegistry,
+		lifeTime:        lifeTime,
 	}, nil
 }
 
@@ -64,6 +88,7 @@ func (j *InMemSessionRegistry) AddToSessionRegistry(ctx context.Context, session
 		return "", errors.Wrap(err, "cannot create the UUID")
 	}
 	session.ID = sessionID.String()
+	session.CreatedAt = time.Now()
 
 	registry := j.sessionRegistry
 
@@ -115,11 +140,79 @@ func (j *InMemSessionRegistry) GetSessionRegistry(ctx context.Context, claim Use
 	return sessionObj, err
 }
 
-// EvictFromSessionRegistry removes the session from the registry for the provided claim
+// EvictFromSessionRegistry removes the session from the registry for the provided claim, evicting a session that has
+// already been evicted is not an error
 func (j *InMemSessionRegistry) EvictFromSessionRegistry(ctx context.Context, claim UserClaims) error {
 	registry := j.sessionRegistry
-	if err := registry.Delete(claim.SessionID); err != nil {
+	if err := registry.Delete(claim.SessionID); err != nil && err != bigcache.ErrEntryNotFound {
 		return errors.Wrap(err, "error while session evict")
 	}
 	return nil
 }
+
+// ListUserSessions iterates over the registry and returns the sessions belonging to the user
+func (j *InMemSessionRegistry) ListUserSessions(ctx context.Context, userID string) ([]Session, error) {
+	var sessions []Session
+
+	iterator := j.sessionRegistry.Iterator()
+	for iterator.SetNext() {
+		entry, err := iterator.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot iterate over the session registry")
+		}
+
+		var sessionObj Session
+		if err = json.Unmarshal(entry.Value(), &sessionObj); err != nil {
+			return nil, errors.Wrap(err, "error while Session json unmarshal")
+		}
+		if sessionObj.UserID == userID {
+			sessions = append(sessions, sessionObj)
+		}
+	}
+	return sessions, nil
+}
+
+// EvictUserSessions removes every session belonging to the user from the registry
+func (j *InMemSessionRegistry) EvictUserSessions(ctx context.Context, userID string) error {
+	sessions, err := j.ListUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		err = j.sessionRegistry.Delete(session.ID)
+		if err != nil && err != bigcache.ErrEntryNotFound {
+			return errors.Wrap(err, "error while session evict")
+		}
+	}
+	return nil
+}
+
+// CountSessions iterates over the registry, the expired sessions are skipped since bigcache only drops them when their
+// shard is written to
+func (j *InMemSessionRegistry) CountSessions(ctx context.Context) (int, error) {
+	count := 0
+	expiredBefore := time.Now().Add(-j.lifeTime)
+
+	iterator := j.sessionRegistry.Iterator()
+	for iterator.SetNext() {
+		entry, err := iterator.Value()
+		if err != nil {
+			return 0, errors.Wrap(err, "cannot iterate over the session registry")
+		}
+
+		var sessionObj Session
+		if err = json.Unmarshal(entry.Value(), &sessionObj); err != nil {
+			return 0, errors.Wrap(err, "error while Session json unmarshal")
+		}
+		if sessionObj.CreatedAt.After(expiredBefore) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Ping always succeeds, the in memory registry has no backend to reach
+func (j *InMemSessionRegistry) Ping(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/reiver/go-pqerror"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"time"
+)
+
+const (
+	// DefaultMaxTxRetries is used when crdb.max_tx_retries is not configured
+	DefaultMaxTxRetries = 10
+	// restartSavepoint is the savepoint name CockroachDB treats as the client-side retry marker
+	restartSavepoint = "cockroach_restart"
+	// initialBackoff is the wait before the first retry, it is doubled after every failed attempt
+	initialBackoff = 5 * time.Millisecond
+	// maxBackoff caps the wait between two attempts
+	maxBackoff = time.Second
+)
+
+var ErrTxRetriesExhausted = errors.New("transaction retries exhausted")
+
+// TxFunc is the unit of work executed by ExecuteTx. It can be invoked more than once, so it must not have side
+// effects outside the transaction.
+type TxFunc func(tx *sql.Tx) error
+
+// ExecuteTx runs fn in a transaction using the SAVEPOINT cockroach_restart protocol. Whenever CockroachDB reports a
+// retryable error fn is rolled back to the savepoint and executed again. The error from fn, the retry exhaustion or
+// the commit failure is returned to the caller.
+func ExecuteTx(ctx context.Context, db *sql.DB, fn TxFunc) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "cannot begin the transaction")
+	}
+
+	defer func() {
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && rbErr != sql.ErrTxDone {
+				log.Error().Err(rbErr).Msg("cannot rollback the transaction")
+			}
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+restartSavepoint); err != nil {
+		return errors.Wrap(err, "cannot create the restart savepoint")
+	}
+
+	maxRetries := viper.GetInt("crdb.max_tx_retries")
+	if maxRetries <= 0 {
+		maxRetries = DefaultMaxTxRetries
+	}
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err = fn(tx)
+		if err == nil {
+			if _, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+restartSavepoint); err == nil {
+				break
+			}
+		}
+
+		if !IsRetryable(err) {
+			return err
+		}
+		if attempt > maxRetries {
+			return errors.Wrapf(ErrTxRetriesExhausted, "after %d attempts: %v", attempt, err)
+		}
+		log.Warn().Err(err).Int("attempt", attempt).Msg("retrying the transaction")
+
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+restartSavepoint); rbErr != nil {
+			err = errors.Wrap(rbErr, "cannot rollback to the restart savepoint")
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = nextBackoff(backoff)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "cannot commit the transaction")
+	}
+	return nil
+}
+
+// nextBackoff doubles the wait between two attempts up to maxBackoff
+func nextBackoff(backoff time.Duration) time.Duration {
+	if backoff *= 2; backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// IsRetryable reports whether err is a CockroachDB transaction restart error (SQLSTATE 40001)
+func IsRetryable(err error) bool {
+	pqError, ok := errors.Cause(err).(*pq.Error)
+	return ok && pqError.Code == pqerror.CodeTransactionRollbackSerializationFailure
+}
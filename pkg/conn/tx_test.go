@@ -0,0 +1,165 @@
+package conn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/reiver/go-pqerror"
+	"github.com/spf13/viper"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a database/sql connector recording the statements and the transaction outcomes, without a database
+type recorder struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (r *recorder) Connect(context.Context) (driver.Conn, error) { return &recorderConn{r}, nil }
+func (r *recorder) Driver() driver.Driver                        { return r }
+func (r *recorder) Open(string) (driver.Conn, error)             { return &recorderConn{r}, nil }
+
+func (r *recorder) record(statement string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, statement)
+}
+
+func (r *recorder) log() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.statements, "; ")
+}
+
+type recorderConn struct{ r *recorder }
+
+func (c *recorderConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *recorderConn) Close() error                        { return nil }
+func (c *recorderConn) Begin() (driver.Tx, error) {
+	c.r.record("BEGIN")
+	return c, nil
+}
+func (c *recorderConn) Commit() error {
+	c.r.record("COMMIT")
+	return nil
+}
+func (c *recorderConn) Rollback() error {
+	c.r.record("ROLLBACK")
+	return nil
+}
+func (c *recorderConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.r.record(query)
+	return driver.RowsAffected(0), nil
+}
+
+// openRecorder returns a DB whose statements are recorded
+func openRecorder() (*sql.DB, *recorder) {
+	r := &recorder{}
+	db := sql.OpenDB(r)
+	db.SetMaxOpenConns(1)
+	return db, r
+}
+
+func TestExecuteTxRetries(t *testing.T) {
+	restart := &pq.Error{Code: pqerror.CodeTransactionRollbackSerializationFailure}
+	failure := errors.New("constraint violated")
+
+	tests := []struct {
+		name     string
+		errs     []error
+		attempts int
+		want     error
+		log      string
+	}{
+		{
+			name:     "committed at once",
+			errs:     []error{nil},
+			attempts: 1,
+			log:      "BEGIN; SAVEPOINT cockroach_restart; RELEASE SAVEPOINT cockroach_restart; COMMIT",
+		},
+		{
+			name:     "retried on restarts",
+			errs:     []error{restart, errors.Wrap(restart, "wrapped"), nil},
+			attempts: 3,
+			log: "BEGIN; SAVEPOINT cockroach_restart; ROLLBACK TO SAVEPOINT cockroach_restart; " +
+				"ROLLBACK TO SAVEPOINT cockroach_restart; RELEASE SAVEPOINT cockroach_restart; COMMIT",
+		},
+		{
+			name:     "not retried on other errors",
+			errs:     []error{failure},
+			attempts: 1,
+			want:     failure,
+			log:      "BEGIN; SAVEPOINT cockroach_restart; ROLLBACK",
+		},
+		{
+			name:     "retries exhausted",
+			errs:     []error{restart, restart, restart, restart},
+			attempts: 3,
+			want:     ErrTxRetriesExhausted,
+			log: "BEGIN; SAVEPOINT cockroach_restart; ROLLBACK TO SAVEPOINT cockroach_restart; " +
+				"ROLLBACK TO SAVEPOINT cockroach_restart; ROLLBACK",
+		},
+	}
+
+	viper.Set("crdb.max_tx_retries", 2)
+	defer viper.Set("crdb.max_tx_retries", nil)
+	for _, tt := range tests {
+		db, r := openRecorder()
+		attempts := 0
+		err := ExecuteTx(context.Background(), db, func(tx *sql.Tx) error {
+			attempts++
+			return tt.errs[attempts-1]
+		})
+		db.Close()
+
+		if errors.Cause(err) != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if attempts != tt.attempts {
+			t.Errorf("%s: %d attempts, want %d", tt.name, attempts, tt.attempts)
+		}
+		if r.log() != tt.log {
+			t.Errorf("%s: got the statements %q, want %q", tt.name, r.log(), tt.log)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&pq.Error{Code: pqerror.CodeTransactionRollbackSerializationFailure}, true},
+		{errors.WithMessage(&pq.Error{Code: "40001"}, "insert"), true},
+		{&pq.Error{Code: pqerror.CodeIntegrityConstraintViolationUniqueViolation}, false},
+		{errors.New("40001"), false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestNextBackoff(t *testing.T) {
+	backoff := initialBackoff
+	for i := 0; i < 20; i++ {
+		next := nextBackoff(backoff)
+		if next > maxBackoff || (backoff < maxBackoff/2 && next != 2*backoff) {
+			t.Fatalf("nextBackoff(%s) = %s", backoff, next)
+		}
+		backoff = next
+	}
+	if backoff != maxBackoff {
+		t.Fatalf("the backoff is %s after 20 attempts, want the %s cap", backoff, maxBackoff)
+	}
+	if got := nextBackoff(700 * time.Millisecond); got != maxBackoff {
+		t.Fatalf("nextBackoff(700ms) = %s, want %s", got, maxBackoff)
+	}
+}
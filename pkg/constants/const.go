@@ -7,4 +7,28 @@ const (
 	MDKeyUsername = "user-name"
 	// MDKeySuperUser context key for storing logged in user's username
 	MDKeySuperUser = "super-user"
+	// MDKeyPrincipalType context key for storing whether the caller is a human or a service account
+	MDKeyPrincipalType = "principal-type"
+	// MDKeyPendingRestore context key for storing whether the logged in user is deleted and pending restore
+	MDKeyPendingRestore = "pending-restore"
+	// MDKeyAPIKeyID context key for storing the ID of the API key the caller authenticated with
+	MDKeyAPIKeyID = "api-key-id"
+	// MDKeyClientID context key for storing the ID of the OAuth2 client the access token of the caller was issued to
+	MDKeyClientID = "client-id"
+	// MDKeyScopes context key for storing the scopes of the API key or OAuth2 access token the caller authenticated with
+	MDKeyScopes = "scopes"
+	// MDKeyRequestID context key for the ID the caller assigned to the request
+	MDKeyRequestID = "x-request-id"
+	// MDKeyForwardedFor context key for the client addresses the REST gateway forwards the request for
+	MDKeyForwardedFor = "x-forwarded-for"
+	// MDKeyGateway context key for the token the REST gateway of this process marks its calls with
+	MDKeyGateway = "x-userapp-gateway"
+)
+
+const (
+	// PrincipalHuman is the users.principal_type of the users signing in with a password
+	PrincipalHuman = "human"
+	// PrincipalService is the users.principal_type of the service accounts, they authenticate with an API key, a
+	// client certificate or their client credentials
+	PrincipalService = "service"
 )
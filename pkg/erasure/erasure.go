@@ -0,0 +1,141 @@
+package erasure
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"time"
+	"user.app/models"
+	"user.app/pkg/conn"
+	"user.app/pkg/query"
+)
+
+const (
+	// TriggerUser is recorded when the users erase their own account
+	TriggerUser = "user"
+	// TriggerAdmin is recorded when a superuser erases the account of another user
+	TriggerAdmin = "admin"
+	// TriggerRetention is recorded when the retention job erases a user whose grace period is over
+	TriggerRetention = "retention"
+
+	// tombstoneDomain is a reserved TLD, the tombstone emails can never be delivered
+	tombstoneDomain = "erased.invalid"
+	// tombstoneBytes is the amount of randomness in a tombstone
+	tombstoneBytes = 16
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+// Erase irreversibly removes the personal data of the user in its own transaction. Erasing a user that has already
+// been erased, even if its row has been purged since, returns the existing receipt. The sessions are evicted by the
+// subscribers of events.UserErased.
+func Erase(ctx context.Context, db *sql.DB, userID, trigger, requestedBy string) (*models.ErasureReceipt, error) {
+	var receipt *models.ErasureReceipt
+	err := conn.ExecuteTx(ctx, db, func(tx *sql.Tx) error {
+		existing, err := query.FindErasureReceipt(ctx, tx, userID)
+		if err == nil {
+			receipt = existing
+			return nil
+		}
+		if err != query.ErrNoRowsFound {
+			return errors.WithMessage(err, "cannot retrieve the erasure receipt")
+		}
+
+		user, err := models.FindUser(ctx, tx, userID)
+		if err != nil {
+			if errors.Cause(err) == sql.ErrNoRows {
+				return ErrUserNotFound
+			}
+			return errors.WithMessage(err, "cannot retrieve the user")
+		}
+
+		receipt, err = erase(ctx, tx, user, trigger, requestedBy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// EraseInTx removes the personal data of the user using the caller's transaction. It is idempotent, the existing receipt
+// is returned for an erased user.
+func EraseInTx(ctx context.Context, exec boil.ContextExecutor, user *models.User, trigger, requestedBy string) (*models.ErasureReceipt, error) {
+	existing, err := query.FindErasureReceipt(ctx, exec, user.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if err != query.ErrNoRowsFound {
+		return nil, errors.WithMessage(err, "cannot retrieve the erasure receipt")
+	}
+	return erase(ctx, exec, user, trigger, requestedBy)
+}
+
+// erase replaces the username and email with random tombstones, drops the password hash, the login history, the
+// API keys, the refresh tokens and the consents, soft deletes the user if it is still live and records the receipt
+func erase(ctx context.Context, exec boil.ContextExecutor, user *models.User, trigger, requestedBy string) (*models.ErasureReceipt, error) {
+	usernameTombstone, err := tombstone()
+	if err != nil {
+		return nil, err
+	}
+	emailTombstone, err := tombstone()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	user.Username = "erased-" + usernameTombstone
+	user.Email = emailTombstone + "@" + tombstoneDomain
+	user.Password = ""
+	user.LastLogin = null.Time{}
+	user.AnonymizedAt = null.TimeFrom(now)
+	columns := []string{
+		models.UserColumns.Username,
+		models.UserColumns.Email,
+		models.UserColumns.Password,
+		models.UserColumns.LastLogin,
+		models.UserColumns.AnonymizedAt,
+	}
+	if user.DeletedAt.Equal(query.NotDeleted) {
+		user.DeletedAt = now
+		columns = append(columns, models.UserColumns.DeletedAt)
+	}
+	if err = query.UpdateUser(ctx, exec, user, columns); err != nil {
+		return nil, errors.WithMessage(err, "cannot replace the personal data")
+	}
+	if err = query.DeleteUserAPIKeys(ctx, exec, user.ID); err != nil {
+		return nil, errors.WithMessage(err, "cannot delete the API keys")
+	}
+	if err = query.DeleteUserRefreshTokens(ctx, exec, user.ID); err != nil {
+		return nil, errors.WithMessage(err, "cannot delete the refresh tokens")
+	}
+	if err = query.DeleteUserConsents(ctx, exec, user.ID); err != nil {
+		return nil, errors.WithMessage(err, "cannot delete the consents")
+	}
+
+	receipt := &models.ErasureReceipt{
+		UserID:   user.ID,
+		Trigger:  trigger,
+		ErasedAt: now,
+	}
+	if len(requestedBy) > 0 {
+		receipt.RequestedBy = null.StringFrom(requestedBy)
+	}
+	if err = query.CreateErasureReceipt(ctx, exec, receipt); err != nil {
+		return nil, errors.WithMessage(err, "cannot record the erasure receipt")
+	}
+	return receipt, nil
+}
+
+// tombstone returns a random hex string, it is not derived from the user's data so it cannot be reversed
+func tombstone() (string, error) {
+	b := make([]byte, tombstoneBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "cannot generate the tombstone")
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/metadata"
+	"sync"
+	"time"
+	"user.app/models"
+	"user.app/pkg/constants"
+	"user.app/pkg/query"
+)
+
+// Type is the kind of a domain event
+type Type string
+
+const (
+	UserCreated     Type = "user.created"
+	UserUpdated     Type = "user.updated"
+	UserDeleted     Type = "user.deleted"
+	UserRestored    Type = "user.restored"
+	UserErased      Type = "user.erased"
+	UserSignedIn    Type = "user.signed_in"
+	PasswordChanged Type = "user.password_changed"
+	APIKeyCreated   Type = "user.api_key_created"
+	APIKeyRevoked   Type = "user.api_key_revoked"
+)
+
+type (
+	// Event is something that happened to a user. It never carries the values of the user's fields, only the names of
+	// the changed columns.
+	Event struct {
+		Type Type
+		// UserID is the user the event is about
+		UserID string
+		// ActorID is the logged-in user who caused the event, empty for the system jobs
+		ActorID        string
+		ChangedColumns []string
+		OccurredAt     time.Time
+	}
+
+	// Handler is an in-process subscriber. It runs synchronously with the executor of the mutation, usually its
+	// transaction, and its error aborts the mutation. Handlers with side effects outside of the database have to be
+	// idempotent since the transaction can be retried or rolled back.
+	Handler func(ctx context.Context, exec boil.ContextExecutor, event Event) error
+
+	// Bus dispatches the published events to the subscribed handlers
+	Bus struct {
+		mu       sync.RWMutex
+		handlers map[Type][]Handler
+		all      []Handler
+	}
+)
+
+var (
+	// DefaultBus is the bus the model hooks publish to
+	DefaultBus = NewBus()
+
+	registerHooks sync.Once
+)
+
+// NewBus is the constructor for the Bus
+func NewBus() *Bus {
+	return &Bus{
+		handlers: make(map[Type][]Handler),
+	}
+}
+
+// Subscribe registers the handler for the given event types, for every event when no type is given
+func (b *Bus) Subscribe(handler Handler, types ...Type) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(types) == 0 {
+		b.all = append(b.all, handler)
+		return
+	}
+	for _, t := range types {
+		b.handlers[t] = append(b.handlers[t], handler)
+	}
+}
+
+// Publish runs the handlers subscribed to the event in their subscription order, it stops at the first failure
+func (b *Bus) Publish(ctx context.Context, exec boil.ContextExecutor, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.all)+len(b.handlers[event.Type]))
+	handlers = append(handlers, b.all...)
+	handlers = append(handlers, b.handlers[event.Type]...)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, exec, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RegisterUserHooks publishes the user mutations to the bus through the sqlboiler hooks. The hooks are global, so
+// only the first call registers them.
+func RegisterUserHooks(bus *Bus) {
+	registerHooks.Do(func() {
+		models.AddUserHook(boil.AfterInsertHook, func(ctx context.Context, exec boil.ContextExecutor, user *models.User) error {
+			actorID := actor(ctx)
+			if len(actorID) == 0 {
+				// users sign themselves up
+				actorID = user.ID
+			}
+			return bus.Publish(ctx, exec, Event{
+				Type:    UserCreated,
+				UserID:  user.ID,
+				ActorID: actorID,
+			})
+		})
+		models.AddUserHook(boil.AfterUpdateHook, func(ctx context.Context, exec boil.ContextExecutor, user *models.User) error {
+			columns := query.ChangedColumns(ctx)
+			return bus.Publish(ctx, exec, Event{
+				Type:           classify(user, columns),
+				UserID:         user.ID,
+				ActorID:        actor(ctx),
+				ChangedColumns: columns,
+			})
+		})
+		models.AddUserHook(boil.AfterDeleteHook, func(ctx context.Context, exec boil.ContextExecutor, user *models.User) error {
+			return bus.Publish(ctx, exec, Event{
+				Type:    UserDeleted,
+				UserID:  user.ID,
+				ActorID: actor(ctx),
+			})
+		})
+	})
+}
+
+// classify derives the event type of an update from the changed columns, the most specific type wins
+func classify(user *models.User, columns []string) Type {
+	changed := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		changed[column] = true
+	}
+
+	switch {
+	case changed[models.UserColumns.AnonymizedAt]:
+		return UserErased
+	case changed[models.UserColumns.DeletedAt] && user.DeletedAt.Equal(query.NotDeleted):
+		return UserRestored
+	case changed[models.UserColumns.DeletedAt]:
+		return UserDeleted
+	case changed[models.UserColumns.Password]:
+		return PasswordChanged
+	}
+	return UserUpdated
+}
+
+// actor returns the logged-in user's id set in the metadata by the authenticator, if any
+func actor(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(constants.MDKeyUserID); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"reflect"
+	"testing"
+	"time"
+	"user.app/models"
+	"user.app/pkg/query"
+)
+
+func TestClassify(t *testing.T) {
+	live := &models.User{DeletedAt: query.NotDeleted}
+	deleted := &models.User{DeletedAt: time.Date(2020, 9, 5, 12, 0, 0, 0, time.UTC)}
+	c := models.UserColumns
+
+	tests := []struct {
+		name    string
+		user    *models.User
+		columns []string
+		want    Type
+	}{
+		{"email", live, []string{c.Email}, UserUpdated},
+		{"username and email", live, []string{c.Username, c.Email, c.UpdatedAt}, UserUpdated},
+		{"no column", live, nil, UserUpdated},
+		{"password", live, []string{c.Password, c.UpdatedAt}, PasswordChanged},
+		{"password and email", live, []string{c.Email, c.Password}, PasswordChanged},
+		{"deleted", deleted, []string{c.DeletedAt}, UserDeleted},
+		{"deleted with its password", deleted, []string{c.Password, c.DeletedAt}, UserDeleted},
+		{"restored", live, []string{c.DeletedAt, c.UpdatedAt}, UserRestored},
+		{"restored with its password", live, []string{c.DeletedAt, c.Password}, UserRestored},
+		{"erased", deleted, []string{c.Username, c.Email, c.Password, c.AnonymizedAt}, UserErased},
+		{"erased and deleted", deleted, []string{c.DeletedAt, c.AnonymizedAt}, UserErased},
+		{"superuser", live, []string{c.IsSuperuser}, UserUpdated},
+	}
+	for _, tt := range tests {
+		if got := classify(tt.user, tt.columns); got != tt.want {
+			t.Errorf("%s: classify(%v) = %s, want %s", tt.name, tt.columns, got, tt.want)
+		}
+	}
+}
+
+func TestPublish(t *testing.T) {
+	bus := NewBus()
+	var got []string
+	record := func(name string, err error) Handler {
+		return func(_ context.Context, _ boil.ContextExecutor, event Event) error {
+			got = append(got, name+":"+string(event.Type))
+			return err
+		}
+	}
+	failure := errors.New("audit failed")
+	bus.Subscribe(record("audit", nil))
+	bus.Subscribe(record("sessions", nil), UserDeleted, PasswordChanged)
+	bus.Subscribe(record("outbox", failure), UserDeleted)
+	bus.Subscribe(record("after", nil), UserDeleted)
+
+	if err := bus.Publish(context.Background(), nil, Event{Type: PasswordChanged}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Publish(context.Background(), nil, Event{Type: UserDeleted}); err != failure {
+		t.Fatalf("got %v, want the error of the failed handler", err)
+	}
+	want := []string{
+		"audit:user.password_changed", "sessions:user.password_changed",
+		"audit:user.deleted", "sessions:user.deleted", "outbox:user.deleted",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
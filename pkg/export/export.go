@@ -0,0 +1,251 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"time"
+	"user.app/message"
+	"user.app/models"
+	"user.app/pkg/auth"
+)
+
+const (
+	// Version of the export document, it is increased on every incompatible change of the document. The version 2
+	// replaced login_history with last_login and added the audit events, the API keys and the OAuth2 consents.
+	Version = 2
+	// ChunkSize is the maximum size of the data sent in one ExportChunk
+	ChunkSize = 64 * 1024
+	// fileName is the name of the document inside the zip archive
+	fileName = "user-data.json"
+)
+
+type (
+	// Document is everything user.app holds about a user
+	Document struct {
+		Version     int       `json:"version"`
+		GeneratedAt time.Time `json:"generated_at"`
+		Profile     Profile   `json:"profile"`
+		// LastLogin is the last successful sign in, the previous ones are not kept
+		LastLogin     *time.Time     `json:"last_login,omitempty"`
+		Sessions      []Session      `json:"sessions"`
+		Roles         []string       `json:"roles"`
+		AuditEvents   []AuditEvent   `json:"audit_events"`
+		APIKeys       []APIKey       `json:"api_keys"`
+		OAuthConsents []OAuthConsent `json:"oauth_consents"`
+	}
+
+	// Profile holds the user's own data, the password hash is never exported
+	Profile struct {
+		ID        string     `json:"id"`
+		Username  string     `json:"username"`
+		Email     string     `json:"email"`
+		CreatedAt time.Time  `json:"created_at"`
+		UpdatedAt time.Time  `json:"updated_at"`
+		DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	}
+
+	// Session is an active session of the user
+	Session struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// AuditEvent is a change made to the user, by the user or by an admin
+	AuditEvent struct {
+		Action        string    `json:"action"`
+		ActorID       string    `json:"actor_id,omitempty"`
+		ChangedFields []string  `json:"changed_fields"`
+		RequestID     string    `json:"request_id,omitempty"`
+		ClientIP      string    `json:"client_ip,omitempty"`
+		CreatedAt     time.Time `json:"created_at"`
+	}
+
+	// APIKey is an API key of the user, its hash is never exported
+	APIKey struct {
+		ID         string     `json:"id"`
+		Name       string     `json:"name"`
+		Prefix     string     `json:"prefix"`
+		Scopes     []string   `json:"scopes"`
+		CreatedAt  time.Time  `json:"created_at"`
+		ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+		LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+		RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	}
+
+	// OAuthConsent holds the scopes the user allowed an OAuth2 client to request
+	OAuthConsent struct {
+		ClientID  string    `json:"client_id"`
+		Scopes    []string  `json:"scopes"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// Records are the rows of the other tables holding data about the user
+	Records struct {
+		AuditEvents   models.AuditEventSlice
+		APIKeys       models.APIKeySlice
+		OAuthConsents models.OauthConsentSlice
+	}
+
+	// ChunkSender sends one chunk of the export, it is implemented by the ExportMyData and ExportUserData streams
+	ChunkSender interface {
+		Send(*message.ExportChunk) error
+	}
+)
+
+// Build assembles the export document of the user
+func Build(user *models.User, sessions []auth.Session, records Records, deletedSentinel time.Time) *Document {
+	doc := &Document{
+		Version:     Version,
+		GeneratedAt: time.Now().UTC(),
+		Profile: Profile{
+			ID:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Sessions:      []Session{},
+		Roles:         []string{"user"},
+		AuditEvents:   []AuditEvent{},
+		APIKeys:       []APIKey{},
+		OAuthConsents: []OAuthConsent{},
+	}
+
+	if !user.DeletedAt.Equal(deletedSentinel) {
+		deletedAt := user.DeletedAt
+		doc.Profile.DeletedAt = &deletedAt
+	}
+	doc.LastLogin = timePtr(user.LastLogin)
+	for _, session := range sessions {
+		doc.Sessions = append(doc.Sessions, Session{
+			ID:        session.ID,
+			Type:      string(session.ClaimType),
+			CreatedAt: session.CreatedAt,
+		})
+	}
+	if user.IsSuperuser.Bool {
+		doc.Roles = append(doc.Roles, "superuser")
+	}
+	for _, event := range records.AuditEvents {
+		doc.AuditEvents = append(doc.AuditEvents, AuditEvent{
+			Action:        event.Action,
+			ActorID:       event.ActorID.String,
+			ChangedFields: append([]string{}, event.ChangedFields...),
+			RequestID:     event.RequestID,
+			ClientIP:      event.ClientIP,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+	for _, key := range records.APIKeys {
+		doc.APIKeys = append(doc.APIKeys, APIKey{
+			ID:         key.ID,
+			Name:       key.Name,
+			Prefix:     key.Prefix,
+			Scopes:     append([]string{}, key.Scopes...),
+			CreatedAt:  key.CreatedAt,
+			ExpiresAt:  timePtr(key.ExpiresAt),
+			LastUsedAt: timePtr(key.LastUsedAt),
+			RevokedAt:  timePtr(key.RevokedAt),
+		})
+	}
+	for _, consent := range records.OAuthConsents {
+		doc.OAuthConsents = append(doc.OAuthConsents, OAuthConsent{
+			ClientID:  consent.ClientID,
+			Scopes:    append([]string{}, consent.Scopes...),
+			CreatedAt: consent.CreatedAt,
+			UpdatedAt: consent.UpdatedAt,
+		})
+	}
+	return doc
+}
+
+func timePtr(t null.Time) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// Stream encodes the document in the requested format and sends it in chunks of at most ChunkSize bytes
+func Stream(doc *Document, format message.ExportFormat, sender ChunkSender) error {
+	w := &chunkWriter{sender: sender}
+
+	switch format {
+	case message.ExportFormat_EXPORT_FORMAT_JSON:
+		w.contentType = "application/json"
+		if err := json.NewEncoder(w).Encode(doc); err != nil {
+			return errors.Wrap(err, "cannot encode the export document")
+		}
+	case message.ExportFormat_EXPORT_FORMAT_ZIP:
+		w.contentType = "application/zip"
+		archive := zip.NewWriter(w)
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     fileName,
+			Method:   zip.Deflate,
+			Modified: doc.GeneratedAt,
+		})
+		if err != nil {
+			return errors.Wrap(err, "cannot create the zip entry")
+		}
+		if err = json.NewEncoder(file).Encode(doc); err != nil {
+			return errors.Wrap(err, "cannot encode the export document")
+		}
+		if err = archive.Close(); err != nil {
+			return errors.Wrap(err, "cannot close the zip archive")
+		}
+	default:
+		return errors.Errorf("unknown export format %v", format)
+	}
+
+	return w.Flush()
+}
+
+// chunkWriter buffers the encoded document and sends it as ExportChunk messages
+type chunkWriter struct {
+	sender      ChunkSender
+	contentType string
+	buf         []byte
+	sent        bool
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		n := ChunkSize - len(w.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+		p = p[n:]
+
+		if len(w.buf) == ChunkSize {
+			if err := w.Flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// Flush sends the buffered data, the first chunk always gets sent so that an empty export still has a content type
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 && w.sent {
+		return nil
+	}
+
+	chunk := &message.ExportChunk{Data: w.buf}
+	if !w.sent {
+		chunk.ContentType = w.contentType
+	}
+	if err := w.sender.Send(chunk); err != nil {
+		return errors.Wrap(err, "cannot send the export chunk")
+	}
+
+	w.sent = true
+	w.buf = make([]byte, 0, ChunkSize)
+	return nil
+}
@@ -0,0 +1,56 @@
+package gateway
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"net/http"
+	"strings"
+	"user.app/message"
+	"user.app/pkg/constants"
+	"user.app/pkg/tracing"
+)
+
+// forwardedHeaders are forwarded as metadata, the request ID and the trace context so that the gRPC server continues
+// the trace of the REST caller
+var forwardedHeaders = map[string]bool{constants.MDKeyRequestID: true}
+
+func init() {
+	for _, header := range tracing.PropagatedHeaders {
+		forwardedHeaders[header] = true
+	}
+}
+
+func headerMatcher(key string) (string, bool) {
+	if key := strings.ToLower(key); forwardedHeaders[key] {
+		return key, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher returns the request ID in X-Request-Id, the other metadata keep the Grpc-Metadata- prefix
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == constants.MDKeyRequestID {
+		return http.CanonicalHeaderKey(key), true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// NewHandler returns the REST/JSON handler of the UserApp service. The requests are proxied to the gRPC server
+// listening on the endpoint, so they go through the same interceptors: the Authorization header is forwarded as is
+// and the gRPC status codes are mapped to their HTTP counterparts. The JSON fields keep the names of the proto fields.
+func NewHandler(ctx context.Context, endpoint string, opts ...grpc.DialOption) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	)
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	if err := message.RegisterUserAppHandlerFromEndpoint(ctx, mux, endpoint, opts); err != nil {
+		return nil, errors.Wrap(err, "cannot register the gateway")
+	}
+	return mux, nil
+}
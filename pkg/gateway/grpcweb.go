@@ -0,0 +1,65 @@
+package gateway
+
+import (
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"net/http"
+	"strings"
+)
+
+var (
+	// defaultAllowedHeaders are the request headers a cross-origin gRPC-Web client can send when
+	// grpc_web.allowed_headers is not configured
+	defaultAllowedHeaders = []string{"authorization", "x-request-id"}
+	// protocolHeaders are sent by the gRPC-Web clients themselves, they are always allowed
+	protocolHeaders = []string{"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout"}
+)
+
+// AllowedOrigins are the origins allowed to call the service with gRPC-Web, "*" allows every origin. The same origin
+// requests are always allowed since the browsers do not check them.
+func AllowedOrigins() []string {
+	return viper.GetStringSlice("grpc_web.allowed_origins")
+}
+
+// AllowedHeaders are the request headers of the cross-origin gRPC-Web requests
+func AllowedHeaders() []string {
+	headers := viper.GetStringSlice("grpc_web.allowed_headers")
+	if len(headers) == 0 {
+		return defaultAllowedHeaders
+	}
+	return headers
+}
+
+// NewGRPCWebHandler translates the gRPC-Web requests, application/grpc-web and application/grpc-web-text, to the
+// in-process gRPC server. The headers become the metadata of the call, the authorization header included, so the
+// requests are authenticated like any gRPC call.
+func NewGRPCWebHandler(server *grpc.Server, allowedOrigins, allowedHeaders []string) *grpcweb.WrappedGrpcServer {
+	origins := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		origins[strings.TrimSuffix(origin, "/")] = true
+	}
+	return grpcweb.WrapServer(server,
+		grpcweb.WithOriginFunc(func(origin string) bool {
+			return origins["*"] || origins[origin]
+		}),
+		grpcweb.WithAllowedRequestHeaders(append(append([]string{}, protocolHeaders...), allowedHeaders...)),
+	)
+}
+
+// Dispatch routes the gRPC requests to the gRPC server when it is given, the gRPC-Web requests and their CORS
+// preflights to the gRPC-Web handler and every other request to the REST handler. cmux matches a connection by its
+// first request while the browsers reuse their connections for both REST and gRPC-Web, so the requests are told apart
+// one by one.
+func Dispatch(grpcServer *grpc.Server, grpcWeb *grpcweb.WrappedGrpcServer, rest http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case grpcWeb.IsGrpcWebRequest(r) || grpcWeb.IsAcceptableGrpcCorsRequest(r):
+			grpcWeb.ServeHTTP(w, r)
+		case grpcServer != nil && r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc"):
+			grpcServer.ServeHTTP(w, r)
+		default:
+			rest.ServeHTTP(w, r)
+		}
+	})
+}
@@ -0,0 +1,260 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultIntervalInSeconds is used when health.interval is not configured
+	DefaultIntervalInSeconds = 10
+	// DefaultTimeoutInSeconds is used when health.timeout is not configured
+	DefaultTimeoutInSeconds = 2
+
+	statusOK       = "ok"
+	statusReady    = "ready"
+	statusNotReady = "not_ready"
+	statusDraining = "draining"
+)
+
+type (
+	// Check returns an error when a dependency of the service cannot be reached
+	Check func(ctx context.Context) error
+
+	namedCheck struct {
+		name  string
+		check Check
+	}
+
+	// Checker runs the checks in the background and publishes the readiness of the service, to the gRPC health service
+	// and to the HTTP probes. The service is ready once every check has passed, until one fails or the server drains.
+	Checker struct {
+		server   *grpchealth.Server
+		services []string
+		interval time.Duration
+		timeout  time.Duration
+		draining chan struct{}
+		drain    sync.Once
+
+		mu      sync.RWMutex
+		checks  []namedCheck
+		results map[string]string
+		checked bool
+	}
+
+	// report is the body of the HTTP probes
+	report struct {
+		Status string            `json:"status"`
+		Checks map[string]string `json:"checks,omitempty"`
+	}
+)
+
+// NewChecker is the constructor for the Checker, the services are the gRPC services whose status it sets in addition
+// to the overall status of the server. They are not serving until the first checks pass.
+func NewChecker(services ...string) *Checker {
+	c := &Checker{
+		server:   grpchealth.NewServer(),
+		services: append([]string{""}, services...),
+		interval: seconds("health.interval", DefaultIntervalInSeconds),
+		timeout:  seconds("health.timeout", DefaultTimeoutInSeconds),
+		draining: make(chan struct{}),
+		results:  make(map[string]string),
+	}
+	c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+func seconds(key string, defaultValue int) time.Duration {
+	value := viper.GetInt(key)
+	if value <= 0 {
+		value = defaultValue
+	}
+	return time.Duration(value) * time.Second
+}
+
+// Register adds a check, it has to be called before Run
+func (c *Checker) Register(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, namedCheck{name: name, check: check})
+}
+
+// RegisterServer registers the grpc.health.v1.Health service on the gRPC server
+func (c *Checker) RegisterServer(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, &service{Server: c.server, draining: c.draining})
+}
+
+// Run checks on every interval until the context is done
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.CheckOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckOnce runs every check concurrently, each one with its own timeout, and publishes the readiness
+func (c *Checker) CheckOnce(ctx context.Context) bool {
+	c.mu.RLock()
+	checks := c.checks
+	c.mu.RUnlock()
+
+	results := make(map[string]string, len(checks))
+	var resultsMu sync.Mutex
+	var wg sync.WaitGroup
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			result := statusOK
+			if err := nc.check(checkCtx); err != nil {
+				log.Warn().Err(err).Str("check", nc.name).Msg("health check failed")
+				result = err.Error()
+			}
+			resultsMu.Lock()
+			results[nc.name] = result
+			resultsMu.Unlock()
+		}(nc)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	c.results = results
+	c.checked = true
+	c.mu.Unlock()
+
+	ready := c.Ready()
+	if ready {
+		c.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		c.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	return ready
+}
+
+// Drain marks the service as not ready for good, the gRPC watchers are sent the status and their streams are ended
+func (c *Checker) Drain() {
+	c.drain.Do(func() {
+		close(c.draining)
+		c.server.Shutdown()
+	})
+}
+
+// Ready returns whether the checks passed and the server is not draining
+func (c *Checker) Ready() bool {
+	select {
+	case <-c.draining:
+		return false
+	default:
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.checked {
+		return false
+	}
+	for _, result := range c.results {
+		if result != statusOK {
+			return false
+		}
+	}
+	return true
+}
+
+// LiveHandler serves /healthz, the process is alive as long as it answers. The dependencies are not checked, a
+// database outage must not get the server restarted.
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, report{Status: statusOK})
+	})
+}
+
+// ReadyHandler serves /readyz with the result of every check, it fails with 503 when the server is not ready
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.RLock()
+		results := make(map[string]string, len(c.results))
+		for name, result := range c.results {
+			results[name] = result
+		}
+		c.mu.RUnlock()
+
+		select {
+		case <-c.draining:
+			writeReport(w, http.StatusServiceUnavailable, report{Status: statusDraining, Checks: results})
+			return
+		default:
+		}
+		if !c.Ready() {
+			writeReport(w, http.StatusServiceUnavailable, report{Status: statusNotReady, Checks: results})
+			return
+		}
+		writeReport(w, http.StatusOK, report{Status: statusReady, Checks: results})
+	})
+}
+
+func (c *Checker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, name := range c.services {
+		c.server.SetServingStatus(name, status)
+	}
+}
+
+func writeReport(w http.ResponseWriter, code int, r report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(r); err != nil {
+		log.Error().Err(err).Msg("cannot write the health report")
+	}
+}
+
+// service is the gRPC health service. It is public, and its watch streams end when the server drains so that they do
+// not hold the graceful stop until its deadline.
+type service struct {
+	*grpchealth.Server
+	draining <-chan struct{}
+}
+
+// AuthFuncOverride lets the probes call the health service without a token
+func (s *service) AuthFuncOverride(ctx context.Context, _ string) (context.Context, error) {
+	return ctx, nil
+}
+
+func (s *service) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.draining:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return s.Server.Watch(req, &watchStream{Health_WatchServer: stream, ctx: ctx})
+}
+
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx context.Context
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,117 @@
+package logging
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"time"
+	"user.app/pkg/constants"
+)
+
+// maxRequestIDLength caps the request IDs sent by the clients, like audit_events.request_id
+const maxRequestIDLength = 100
+
+// serverErrors are the codes logged as errors, the others are the outcome of the request of the client
+var serverErrors = map[codes.Code]bool{
+	codes.Unknown:     true,
+	codes.Internal:    true,
+	codes.DataLoss:    true,
+	codes.Unavailable: true,
+}
+
+// UnaryServerInterceptor attaches the logger of the request to the context and logs the call once it is handled. The
+// request ID sent by the client in x-request-id is kept, a new one is assigned otherwise, and it is sent back in the
+// header of the response.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, requestID := withRequestLogger(ctx, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(constants.MDKeyRequestID, requestID)); err != nil {
+			Ctx(ctx).Warn().Err(err).Msg("cannot send the request id")
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of the streams, they are logged when they end
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestLogger(stream.Context(), info.FullMethod)
+		if err := stream.SetHeader(metadata.Pairs(constants.MDKeyRequestID, requestID)); err != nil {
+			Ctx(ctx).Warn().Err(err).Msg("cannot send the request id")
+		}
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		start := time.Now()
+		err := handler(srv, wrapped)
+		logCall(ctx, start, err)
+		return err
+	}
+}
+
+// withRequestLogger returns the context carrying the logger of the request. The request ID is also set in the
+// incoming metadata, the audit events record it.
+func withRequestLogger(ctx context.Context, fullMethod string) (context.Context, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	var requestID string
+	if values := md.Get(constants.MDKeyRequestID); len(values) > 0 && len(values[0]) > 0 {
+		requestID = values[0]
+		if len(requestID) > maxRequestIDLength {
+			requestID = requestID[:maxRequestIDLength]
+		}
+	} else {
+		id, err := uuid.NewV4()
+		if err != nil {
+			log.Error().Err(err).Msg("cannot create the request id")
+		}
+		requestID = id.String()
+	}
+	md.Set(constants.MDKeyRequestID, requestID)
+	ctx = metadata.NewIncomingContext(ctx, md)
+
+	fields := log.Logger.With().
+		Str("request_id", requestID).
+		Str("method", fullMethod)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = fields.Str("peer", p.Addr.String())
+	}
+	if forwardedFor := md.Get(constants.MDKeyForwardedFor); len(forwardedFor) > 0 {
+		fields = fields.Strs("forwarded_for", forwardedFor)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields = fields.Str("trace_id", spanContext.TraceID().String())
+	}
+	logger := fields.Logger()
+	return logger.WithContext(ctx), requestID
+}
+
+func logCall(ctx context.Context, start time.Time, err error) {
+	code := status.Code(err)
+	var event *zerolog.Event
+	if serverErrors[code] {
+		event = Ctx(ctx).Error().Err(err)
+	} else {
+		event = Ctx(ctx).Info()
+	}
+	event.Str("code", code.String()).
+		Dur("duration", time.Since(start)).
+		Msg("call handled")
+}
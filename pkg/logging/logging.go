@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+)
+
+const (
+	// FormatJSON and FormatConsole are the values of log.format
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+// Configure sets the level and the format of the global logger from the [log] section of the configuration, the
+// level defaults to info and the format to json
+func Configure() error {
+	level := zerolog.InfoLevel
+	if name := viper.GetString("log.level"); len(name) > 0 {
+		var err error
+		if level, err = zerolog.ParseLevel(strings.ToLower(name)); err != nil {
+			return errors.Wrapf(err, "invalid log.level %q", name)
+		}
+	}
+	zerolog.SetGlobalLevel(level)
+
+	switch format := viper.GetString("log.format"); format {
+	case "", FormatJSON:
+	case FormatConsole:
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	default:
+		return errors.Errorf("unsupported log.format %q, it must be json or console", format)
+	}
+	return nil
+}
+
+// Ctx returns the logger of the request the context belongs to, with its request ID, method, peer and user, and the
+// global logger outside of the requests
+func Ctx(ctx context.Context) *zerolog.Logger {
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+	return &log.Logger
+}
+
+// Set adds the field to the logger of the request, it is on every line logged for the request from then on
+func Set(ctx context.Context, key, value string) {
+	logger := zerolog.Ctx(ctx)
+	if logger.GetLevel() == zerolog.Disabled {
+		return
+	}
+	logger.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str(key, value)
+	})
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"database/sql"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"regexp"
+	"time"
+)
+
+// literals matches the quoted strings of the statements, they are replaced before the statements are logged
+var literals = regexp.MustCompile(`'(?:[^']|'')*'`)
+
+// executor logs every statement run with a context at debug level, on the logger of the request. The arguments are
+// never logged, only their number, and the literals of the statement are redacted.
+type executor struct {
+	boil.ContextExecutor
+}
+
+// Executor wraps the executor of the queries, the sqlboiler models and hooks use the wrapped executor too
+func Executor(exec boil.ContextExecutor) boil.ContextExecutor {
+	if _, ok := exec.(executor); ok {
+		return exec
+	}
+	return executor{ContextExecutor: exec}
+}
+
+func (e executor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	result, err := e.ContextExecutor.ExecContext(ctx, query, args...)
+	logStatement(ctx, query, len(args), start, err)
+	return result, err
+}
+
+func (e executor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := e.ContextExecutor.QueryContext(ctx, query, args...)
+	logStatement(ctx, query, len(args), start, err)
+	return rows, err
+}
+
+func (e executor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := e.ContextExecutor.QueryRowContext(ctx, query, args...)
+	logStatement(ctx, query, len(args), start, row.Err())
+	return row
+}
+
+func logStatement(ctx context.Context, query string, args int, start time.Time, err error) {
+	event := Ctx(ctx).Debug()
+	if !event.Enabled() {
+		return
+	}
+	event.Str("statement", Redact(query)).
+		Int("args", args).
+		Dur("duration", time.Since(start)).
+		Err(err).
+		Msg("sql")
+}
+
+// Redact replaces the quoted literals of the statement
+func Redact(query string) string {
+	return literals.ReplaceAllString(query, "'?'")
+}
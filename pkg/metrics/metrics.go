@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"net/http"
+	"time"
+)
+
+const (
+	namespace = "userapp"
+
+	// DefaultPath is used when metrics.path is not configured
+	DefaultPath = "/metrics"
+
+	// SignInSuccess and the other outcomes label the sign ins
+	SignInSuccess     = "success"
+	SignInBadPassword = "bad_password"
+	SignInUnknownUser = "unknown_user"
+	// SignInLocked is the outcome of the sign ins refused to a locked account. The accounts are never locked for now,
+	// the series is exported so that the dashboards and alerts do not change once they are.
+	SignInLocked = "locked"
+	// SignInServiceAccount is the outcome of the sign ins refused to a service account, they have no password
+	SignInServiceAccount = "service_account"
+	SignInError          = "error"
+)
+
+var signInOutcomes = []string{
+	SignInSuccess, SignInBadPassword, SignInUnknownUser, SignInLocked, SignInServiceAccount, SignInError,
+}
+
+var (
+	// Registry holds every metric of the service, it is served by Handler
+	Registry = prometheus.NewRegistry()
+
+	// serverMetrics count the RPCs by method and status code and observe their latency
+	serverMetrics = grpc_prometheus.NewServerMetrics()
+
+	signIns = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ins_total",
+		Help:      "Sign ins by outcome.",
+	}, []string{"outcome"})
+
+	signUps = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "sign_ups_total",
+		Help:      "Users created.",
+	})
+
+	deletions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_deletions_total",
+		Help:      "Users who deleted their account.",
+	})
+)
+
+func init() {
+	serverMetrics.EnableHandlingTimeHistogram()
+	for _, outcome := range signInOutcomes {
+		signIns.WithLabelValues(outcome)
+	}
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		serverMetrics,
+		signIns,
+		signUps,
+		deletions,
+	)
+}
+
+// UnaryServerInterceptor records the unary RPCs, it comes first in the chain so that the rejected calls are counted
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return serverMetrics.UnaryServerInterceptor()
+}
+
+// StreamServerInterceptor records the streaming RPCs, the latency of a stream is its whole duration
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return serverMetrics.StreamServerInterceptor()
+}
+
+// InitializeMethods exports the series of every method of the server at 0, it has to be called once the services are
+// registered
+func InitializeMethods(s *grpc.Server) {
+	serverMetrics.InitializeMetrics(s)
+}
+
+// SignIn counts a sign in with its outcome
+func SignIn(outcome string) {
+	signIns.WithLabelValues(outcome).Inc()
+}
+
+// SignUp counts a created user
+func SignUp() {
+	signUps.Inc()
+}
+
+// Deletion counts a deleted user
+func Deletion() {
+	deletions.Inc()
+}
+
+// RegisterSessionGauge exports the number of active sessions, counted on every scrape
+func RegisterSessionGauge(count func(ctx context.Context) (int, error)) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sessions",
+		Help:      "Active sessions in the session registry.",
+	}, func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		sessions, err := count(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("cannot count the sessions")
+			return 0
+		}
+		return float64(sessions)
+	}))
+}
+
+// RegisterDB exports the statistics of the connection pool
+func RegisterDB(db *sql.DB) {
+	Registry.MustRegister(newDBStatsCollector(db))
+}
+
+// Path is the path of the metrics endpoint, metrics.path in the configuration
+func Path() string {
+	path := viper.GetString("metrics.path")
+	if len(path) == 0 {
+		return DefaultPath
+	}
+	return path
+}
+
+// Enabled tells whether the metrics endpoint is served, unless metrics.enabled is set to false
+func Enabled() bool {
+	if !viper.IsSet("metrics.enabled") {
+		return true
+	}
+	return viper.GetBool("metrics.enabled")
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,119 @@
+package normalize
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/secure/precis"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidUsername  = errors.New("username contains disallowed characters")
+	ErrMixedScript      = errors.New("username mixes characters from different scripts")
+	ErrInvalidEmail     = errors.New("email address is malformed")
+	ErrInvalidDomain    = errors.New("email domain is not a valid host name")
+	scriptsToCheck      = []*unicode.RangeTable{unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Armenian, unicode.Georgian, unicode.Hebrew, unicode.Arabic, unicode.Devanagari, unicode.Thai, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul}
+	allowedScriptGroups = [][]*unicode.RangeTable{
+		{unicode.Han, unicode.Hiragana, unicode.Katakana},
+		{unicode.Han, unicode.Hangul},
+	}
+)
+
+// Username returns the canonical form of a username which is what gets stored. It is NFKC normalized and checked
+// against the PRECIS UsernameCasePreserved profile, the case is kept for display. Usernames mixing scripts, like a
+// Cyrillic "а" inside a Latin name, are rejected because they are visually confusable with other usernames.
+func Username(username string) (string, error) {
+	canonical, err := precis.UsernameCasePreserved.String(norm.NFKC.String(username))
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidUsername, err.Error())
+	}
+
+	if isMixedScript(canonical) {
+		return "", ErrMixedScript
+	}
+	return canonical, nil
+}
+
+// UsernameKey returns the case-insensitive form of a username used to compare usernames in memory. It never fails,
+// an input that cannot be normalized is only NFKC normalized and lower cased.
+func UsernameKey(username string) string {
+	if canonical, err := Username(username); err == nil {
+		return strings.ToLower(canonical)
+	}
+	return strings.ToLower(norm.NFKC.String(username))
+}
+
+// Email returns the canonical form of an email address: the local part is NFKC normalized and the domain is
+// converted to its lower cased ASCII (punycode) form.
+func Email(email string) (string, error) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 {
+		return "", ErrInvalidEmail
+	}
+
+	local := norm.NFKC.String(email[:at])
+	domain, err := idna.Lookup.ToASCII(strings.ToLower(norm.NFKC.String(email[at+1:])))
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidDomain, err.Error())
+	}
+	return local + "@" + domain, nil
+}
+
+// EmailKey returns the case-insensitive form of an email address, like UsernameKey it never fails
+func EmailKey(email string) string {
+	if canonical, err := Email(email); err == nil {
+		return strings.ToLower(canonical)
+	}
+	return strings.ToLower(norm.NFKC.String(email))
+}
+
+// isMixedScript reports whether the letters of s belong to more than one script, apart from the script combinations
+// used together in CJK writing
+func isMixedScript(s string) bool {
+	var used []*unicode.RangeTable
+	for _, r := range s {
+		for _, script := range scriptsToCheck {
+			if unicode.Is(script, r) {
+				used = appendScript(used, script)
+				break
+			}
+		}
+	}
+
+	if len(used) <= 1 {
+		return false
+	}
+	for _, group := range allowedScriptGroups {
+		if containsAll(group, used) {
+			return false
+		}
+	}
+	return true
+}
+
+func appendScript(scripts []*unicode.RangeTable, script *unicode.RangeTable) []*unicode.RangeTable {
+	for _, s := range scripts {
+		if s == script {
+			return scripts
+		}
+	}
+	return append(scripts, script)
+}
+
+func containsAll(group, scripts []*unicode.RangeTable) bool {
+	for _, script := range scripts {
+		found := false
+		for _, s := range group {
+			if s == script {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
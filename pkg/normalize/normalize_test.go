@@ -0,0 +1,96 @@
+package normalize
+
+import (
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func TestUsername(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		want     string
+		err      error
+	}{
+		{"ascii", "alice", "alice", nil},
+		{"case preserved", "Alice", "Alice", nil},
+		{"fullwidth", "ａｌｉｃｅ", "alice", nil},
+		{"ligature", "ﬁona", "fiona", nil},
+		{"composed", "josé", "josé", nil},
+		{"cyrillic", "иван", "иван", nil},
+		{"japanese", "山田たろう", "山田たろう", nil},
+		{"korean", "김민준", "김민준", nil},
+		{"cyrillic a in latin", "pаypal", "", ErrMixedScript},
+		{"latin o in greek", "γιoργος", "", ErrMixedScript},
+		{"space", "ali ce", "", ErrInvalidUsername},
+		{"control character", "alice\u0007", "", ErrInvalidUsername},
+	}
+	for _, tt := range tests {
+		got, err := Username(tt.username)
+		if errors.Cause(err) != tt.err || got != tt.want {
+			t.Errorf("%s: Username(%q) = %q, %v, want %q, %v", tt.name, tt.username, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestUsernameKey(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"Alice", "aLICE", true},
+		{"ａｌｉｃｅ", "ALICE", true},
+		{"Иван", "иван", true},
+		{"pаypal", "paypal", false},
+		{"alice", "alice2", false},
+	}
+	for _, tt := range tests {
+		if equal := UsernameKey(tt.a) == UsernameKey(tt.b); equal != tt.equal {
+			t.Errorf("UsernameKey(%q) == UsernameKey(%q) is %v, want %v", tt.a, tt.b, equal, tt.equal)
+		}
+	}
+}
+
+func TestEmail(t *testing.T) {
+	tests := []struct {
+		name  string
+		email string
+		want  string
+		err   error
+	}{
+		{"ascii", "alice@example.com", "alice@example.com", nil},
+		{"local part case preserved", "Alice@Example.COM", "Alice@example.com", nil},
+		{"idn domain", "alice@bücher.example", "alice@xn--bcher-kva.example", nil},
+		{"idn domain upper case", "alice@BÜCHER.example", "alice@xn--bcher-kva.example", nil},
+		{"fullwidth domain", "alice@ｅｘａｍｐｌｅ.com", "alice@example.com", nil},
+		{"fullwidth local part", "ａｌｉｃｅ@example.com", "alice@example.com", nil},
+		{"last at", "\"a@b\"@example.com", "\"a@b\"@example.com", nil},
+		{"no domain", "alice@", "", ErrInvalidEmail},
+		{"no local part", "@example.com", "", ErrInvalidEmail},
+		{"no at", "alice.example.com", "", ErrInvalidEmail},
+		{"invalid domain", "alice@exa mple.com", "", ErrInvalidDomain},
+	}
+	for _, tt := range tests {
+		got, err := Email(tt.email)
+		if errors.Cause(err) != tt.err || got != tt.want {
+			t.Errorf("%s: Email(%q) = %q, %v, want %q, %v", tt.name, tt.email, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestEmailKey(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		equal bool
+	}{
+		{"Alice@Example.COM", "alice@example.com", true},
+		{"alice@BÜCHER.example", "alice@xn--bcher-kva.example", true},
+		{"ａｌｉｃｅ@example.com", "ALICE@EXAMPLE.COM", true},
+		{"alice@example.com", "alice@example.org", false},
+	}
+	for _, tt := range tests {
+		if equal := EmailKey(tt.a) == EmailKey(tt.b); equal != tt.equal {
+			t.Errorf("EmailKey(%q) == EmailKey(%q) is %v, want %v", tt.a, tt.b, equal, tt.equal)
+		}
+	}
+}
@@ -0,0 +1,495 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"github.com/volatiletech/null/v8"
+	"html/template"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/logging"
+	"user.app/pkg/normalize"
+	"user.app/pkg/query"
+)
+
+const (
+	// AuthorizePath serves the login and consent page of the authorization code grant
+	AuthorizePath = "/oauth/authorize"
+
+	// codeChallengeS256 is the only PKCE method accepted, plain would send the verifier along the code
+	codeChallengeS256 = "S256"
+	// codeLifetime is how long an authorization code can be exchanged
+	codeLifetime = time.Minute
+	// maxNonceLength is the length of authorization_codes.nonce
+	maxNonceLength = 255
+
+	// loginCookie holds the token of the session of the login page, csrfCookie the token the forms are checked against
+	loginCookie = "userapp_login"
+	csrfCookie  = "userapp_csrf"
+	csrfBytes   = 16
+)
+
+// pkceValue matches the code verifiers and the S256 code challenges of RFC 7636
+var pkceValue = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// scopeDescriptions are shown on the consent page
+var scopeDescriptions = map[string]string{
+	ScopeOpenID:     "Sign you in with your user.app account",
+	ScopeProfile:    "See your username",
+	ScopeEmail:      "See your email address",
+	auth.ScopeRead:  "Export your data and list your API keys",
+	auth.ScopeWrite: "Update your account",
+	auth.ScopeAdmin: "Call the admin methods with your privileges",
+}
+
+var pageTemplate = template.Must(template.New("authorize").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Sign in - user.app</title>
+<style>
+body { font-family: sans-serif; max-width: 24rem; margin: 4rem auto; padding: 0 1rem; }
+label, input, button { display: block; width: 100%; margin: 0.5rem 0; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+{{if .Fatal}}
+<h1>Sign in failed</h1>
+<p class="error">{{.Error}}</p>
+{{else}}
+<h1>Sign in to {{.ClientName}}</h1>
+{{with .Error}}<p class="error">{{.}}</p>{{end}}
+<form method="post" action="{{.Action}}">
+{{range .Hidden}}<input type="hidden" name="{{.Name}}" value="{{.Value}}">
+{{end}}
+{{if .Username}}
+<p>Signed in as <strong>{{.Username}}</strong>.</p>
+{{else}}
+<label>Username <input name="username" autocomplete="username" required autofocus></label>
+<label>Password <input name="password" type="password" autocomplete="current-password" required></label>
+{{end}}
+<p>{{.ClientName}} will be able to:</p>
+<ul>
+{{range .Scopes}}<li>{{.}}</li>
+{{end}}
+</ul>
+<button name="action" value="allow">Allow</button>
+<button name="action" value="deny" formnovalidate>Deny</button>
+</form>
+{{end}}
+</body>
+</html>
+`))
+
+type (
+	// authorizeRequest holds the parameters of the authorization request, they are carried by the form of the page
+	authorizeRequest struct {
+		ClientID            string
+		RedirectURI         string
+		ResponseType        string
+		Scope               string
+		State               string
+		Nonce               string
+		CodeChallenge       string
+		CodeChallengeMethod string
+		Prompt              string
+	}
+
+	// page is the login and consent page, or the error page when Fatal is set
+	page struct {
+		Fatal      bool
+		Error      string
+		Action     string
+		ClientName string
+		Scopes     []string
+		Username   string
+		Hidden     []hiddenField
+	}
+
+	hiddenField struct {
+		Name  string
+		Value string
+	}
+)
+
+func parseAuthorizeRequest(form url.Values) authorizeRequest {
+	return authorizeRequest{
+		ClientID:            form.Get("client_id"),
+		RedirectURI:         form.Get("redirect_uri"),
+		ResponseType:        form.Get("response_type"),
+		Scope:               form.Get("scope"),
+		State:               form.Get("state"),
+		Nonce:               form.Get("nonce"),
+		CodeChallenge:       form.Get("code_challenge"),
+		CodeChallengeMethod: form.Get("code_challenge_method"),
+		Prompt:              form.Get("prompt"),
+	}
+}
+
+// authorize serves the authorization endpoint of the authorization code grant. A browser signed in on the login page
+// gets a code at once for the scopes the user has already consented to, the page asks for the password and the
+// consent otherwise. The errors are sent to the redirect URI once it is known to belong to the client.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+	if err := r.ParseForm(); err != nil {
+		s.renderError(w, http.StatusBadRequest, "The request is malformed.")
+		return
+	}
+	req := parseAuthorizeRequest(r.Form)
+
+	client, err := query.FindOAuthClient(ctx, s.db, req.ClientID)
+	if err != nil && err != query.ErrNoRowsFound {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the OAuth2 client")
+		s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+		return
+	}
+	if err == query.ErrNoRowsFound || client.RevokedAt.Valid {
+		s.renderError(w, http.StatusBadRequest, "The application is not registered.")
+		return
+	}
+	redirectURI := req.RedirectURI
+	if len(redirectURI) == 0 && len(client.RedirectUris) == 1 {
+		redirectURI = client.RedirectUris[0]
+	}
+	if !contains(client.RedirectUris, redirectURI) {
+		s.renderError(w, http.StatusBadRequest, "The redirect URI is not registered for the application.")
+		return
+	}
+
+	redirect := func(params url.Values) {
+		target, _ := url.Parse(redirectURI)
+		values := target.Query()
+		for key := range params {
+			values.Set(key, params.Get(key))
+		}
+		if len(req.State) > 0 {
+			values.Set("state", req.State)
+		}
+		target.RawQuery = values.Encode()
+		http.Redirect(w, r, target.String(), http.StatusFound)
+	}
+	fail := func(code, description string) {
+		redirect(url.Values{"error": {code}, "error_description": {description}})
+	}
+
+	if !contains(client.GrantTypes, GrantAuthorizationCode) {
+		fail("unauthorized_client", "the client is not registered for the authorization_code grant")
+		return
+	}
+	if req.ResponseType != "code" {
+		fail("unsupported_response_type", "the response_type must be code")
+		return
+	}
+	if !contains(strings.Fields(req.Scope), ScopeOpenID) {
+		fail("invalid_scope", "the openid scope is required")
+		return
+	}
+	scopes, oauthErr := grantedScopes(req.Scope, client.Scopes)
+	if oauthErr != nil {
+		fail(oauthErr.Code, oauthErr.Description)
+		return
+	}
+	if req.CodeChallengeMethod != codeChallengeS256 || !pkceValue.MatchString(req.CodeChallenge) {
+		fail("invalid_request", "PKCE is required, with the S256 code_challenge_method")
+		return
+	}
+	if len(req.Nonce) > maxNonceLength {
+		fail("invalid_request", "the nonce is too long")
+		return
+	}
+	prompts := strings.Fields(req.Prompt)
+	if contains(prompts, "none") && len(prompts) > 1 {
+		fail("invalid_request", "prompt=none cannot be combined with another prompt")
+		return
+	}
+
+	pg := page{
+		Action:     AuthorizePath,
+		ClientName: client.Name,
+		Hidden:     hiddenFields(req),
+	}
+	for _, scope := range scopes {
+		pg.Scopes = append(pg.Scopes, scopeDescriptions[scope])
+	}
+
+	var (
+		user      *models.User
+		authTime  time.Time
+		loginFlow = r.Method == http.MethodGet && contains(prompts, "login")
+	)
+	if !loginFlow {
+		if user, authTime, err = s.loginUser(r); err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot verify the login cookie")
+			s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+			return
+		}
+	}
+
+	if r.Method == http.MethodGet {
+		if user == nil {
+			if contains(prompts, "none") {
+				fail("login_required", "the user is not signed in")
+				return
+			}
+			s.render(w, r, http.StatusOK, pg)
+			return
+		}
+
+		consented, err := s.consented(ctx, user.ID, client.ID, scopes)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the consent")
+			s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+			return
+		}
+		if !consented || contains(prompts, "consent") {
+			if contains(prompts, "none") {
+				fail("consent_required", "the user has not consented to the scopes")
+				return
+			}
+			pg.Username = user.Username
+			s.render(w, r, http.StatusOK, pg)
+			return
+		}
+	} else {
+		if !validCSRF(r) {
+			s.renderError(w, http.StatusBadRequest, "The form has expired, go back to the application and try again.")
+			return
+		}
+		if r.PostForm.Get("action") != "allow" {
+			fail("access_denied", "the user denied the request")
+			return
+		}
+
+		// the form carries the credentials when the user is not signed in or signs in again
+		if user == nil || len(r.PostForm.Get("username")) > 0 {
+			if wait := s.wait(r, normalize.UsernameKey(r.PostForm.Get("username"))); wait > 0 {
+				setRetryAfter(w, wait)
+				pg.Error = "Too many sign in attempts, try again later."
+				s.render(w, r, http.StatusTooManyRequests, pg)
+				return
+			}
+			var token *auth.Token
+			user, token, err = s.authenticator.AuthenticateLogin(ctx, r.PostForm.Get("username"), r.PostForm.Get("password"))
+			switch err {
+			case nil:
+			case auth.ErrInvalidCredentialsError:
+				pg.Error = "Invalid username or password."
+				s.render(w, r, http.StatusUnauthorized, pg)
+				return
+			case auth.ErrLoginPendingRestore:
+				pg.Error = "This account has been deleted, restore it before signing in."
+				s.render(w, r, http.StatusForbidden, pg)
+				return
+			default:
+				logging.Ctx(ctx).Error().Err(err).Msg("cannot sign the user in")
+				s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+				return
+			}
+			s.replaceLogin(w, r, token)
+			authTime = time.Now()
+		}
+
+		if err = s.saveConsent(ctx, user.ID, client.ID, scopes); err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot save the consent")
+			s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+			return
+		}
+	}
+
+	code, err := random("", secretBytes)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot generate the authorization code")
+		s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+		return
+	}
+	authorizationCode := &models.OauthAuthorizationCode{
+		CodeHash:      hash(code),
+		ClientID:      client.ID,
+		UserID:        user.ID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      authTime,
+		ExpiresAt:     time.Now().Add(codeLifetime),
+		CreatedAt:     time.Now(),
+	}
+	if len(req.Nonce) > 0 {
+		authorizationCode.Nonce = null.StringFrom(req.Nonce)
+	}
+	if err = query.CreateAuthorizationCode(ctx, s.db, authorizationCode); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot save the authorization code")
+		s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+		return
+	}
+	redirect(url.Values{"code": {code}})
+}
+
+// loginUser returns the live user signed in on the login page and when it signed in, nil when there is none
+func (s *Server) loginUser(r *http.Request) (*models.User, time.Time, error) {
+	cookie, err := r.Cookie(loginCookie)
+	if err != nil {
+		return nil, time.Time{}, nil
+	}
+	claims, session, err := s.authenticator.VerifyLogin(r.Context(), cookie.Value)
+	if err != nil {
+		if err == auth.ErrInvalidCredentialsError {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+
+	user, err := query.FindUser(r.Context(), s.db, &query.UserFilter{ID: claims.ID})
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	return user, session.CreatedAt, nil
+}
+
+// replaceLogin sets the login cookie, the session of the previous one is evicted
+func (s *Server) replaceLogin(w http.ResponseWriter, r *http.Request, token *auth.Token) {
+	if cookie, err := r.Cookie(loginCookie); err == nil {
+		if claims, _, err := s.authenticator.VerifyLogin(r.Context(), cookie.Value); err == nil {
+			if err = s.authenticator.EvictSession(r.Context(), claims.SessionID); err != nil {
+				logging.Ctx(r.Context()).Error().Err(err).Msg("cannot evict the previous login session")
+			}
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     loginCookie,
+		Value:    token.AccessToken,
+		Path:     "/oauth",
+		MaxAge:   auth.DefaultLifetime * int(time.Hour/time.Second),
+		Secure:   s.secureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// consented tells whether the user has already consented to every scope for the client
+func (s *Server) consented(ctx context.Context, userID, clientID string, scopes []string) (bool, error) {
+	consent, err := query.FindConsent(ctx, s.db, userID, clientID)
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, scope := range scopes {
+		if !contains(consent.Scopes, scope) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// saveConsent adds the scopes to the consent the user gave to the client
+func (s *Server) saveConsent(ctx context.Context, userID, clientID string, scopes []string) error {
+	consent, err := query.FindConsent(ctx, s.db, userID, clientID)
+	if err != nil {
+		if err != query.ErrNoRowsFound {
+			return err
+		}
+		consent = &models.OauthConsent{
+			UserID:    userID,
+			ClientID:  clientID,
+			CreatedAt: time.Now(),
+		}
+	}
+	for _, scope := range scopes {
+		if !contains(consent.Scopes, scope) {
+			consent.Scopes = append(consent.Scopes, scope)
+		}
+	}
+	consent.UpdatedAt = time.Now()
+	return query.SaveConsent(ctx, s.db, consent)
+}
+
+// render writes the page with a CSRF token, the one of the cookie is reused so that the other tabs keep working
+func (s *Server) render(w http.ResponseWriter, r *http.Request, code int, pg page) {
+	token := ""
+	if cookie, err := r.Cookie(csrfCookie); err == nil && len(cookie.Value) == 2*csrfBytes {
+		token = cookie.Value
+	} else {
+		var err error
+		if token, err = random("", csrfBytes); err != nil {
+			logging.Ctx(r.Context()).Error().Err(err).Msg("cannot generate the CSRF token")
+			s.renderError(w, http.StatusInternalServerError, "Something went wrong, try again later.")
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     csrfCookie,
+			Value:    token,
+			Path:     AuthorizePath,
+			Secure:   s.secureCookies,
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+	pg.Hidden = append(pg.Hidden, hiddenField{Name: "csrf", Value: token})
+	writePage(w, code, pg)
+}
+
+// renderError writes the error page, used when the error cannot be sent to the client
+func (s *Server) renderError(w http.ResponseWriter, code int, message string) {
+	writePage(w, code, page{Fatal: true, Error: message})
+}
+
+func writePage(w http.ResponseWriter, code int, pg page) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	_ = pageTemplate.Execute(w, pg)
+}
+
+// validCSRF checks the token of the form against the one of the cookie
+func validCSRF(r *http.Request) bool {
+	cookie, err := r.Cookie(csrfCookie)
+	if err != nil || len(cookie.Value) == 0 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(r.PostForm.Get("csrf"))) == 1
+}
+
+func hiddenFields(req authorizeRequest) []hiddenField {
+	return []hiddenField{
+		{Name: "client_id", Value: req.ClientID},
+		{Name: "redirect_uri", Value: req.RedirectURI},
+		{Name: "response_type", Value: req.ResponseType},
+		{Name: "scope", Value: req.Scope},
+		{Name: "state", Value: req.State},
+		{Name: "nonce", Value: req.Nonce},
+		{Name: "code_challenge", Value: req.CodeChallenge},
+		{Name: "code_challenge_method", Value: req.CodeChallengeMethod},
+		// prompt=login is satisfied by the form, it is not carried over
+	}
+}
+
+// verifyCodeChallenge checks the code verifier of the token request against the S256 challenge of the code
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if !pkceValue.MatchString(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
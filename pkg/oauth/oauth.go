@@ -0,0 +1,398 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/events"
+	"user.app/pkg/logging"
+	"user.app/pkg/query"
+	"user.app/pkg/ratelimit"
+	"user.app/pkg/transport"
+)
+
+const (
+	// TokenPath and the other paths are served on the HTTP listener of the service port
+	TokenPath      = "/oauth/token"
+	IntrospectPath = "/oauth/introspect"
+	RevokePath     = "/oauth/revoke"
+
+	// GrantAuthorizationCode and the other grant types are the ones a client can be registered with
+	GrantAuthorizationCode = "authorization_code"
+	GrantClientCredentials = "client_credentials"
+	GrantRefreshToken      = "refresh_token"
+
+	// ClientIDPrefix and the other prefixes tell the credentials apart and let the secret scanners find the secrets
+	ClientIDPrefix     = "uac_"
+	ClientSecretPrefix = "uas_"
+	RefreshTokenPrefix = "uar_"
+
+	// DefaultAccessTokenLifetime is used when oauth.access_token_lifetime is not configured
+	DefaultAccessTokenLifetime = time.Hour
+	// DefaultRefreshTokenLifetime is used when oauth.refresh_token_lifetime is not configured
+	DefaultRefreshTokenLifetime = 30 * 24 * time.Hour
+	// DefaultIssuer is used when oauth.issuer is not configured
+	DefaultIssuer = "http://localhost:9688"
+
+	clientIDBytes = 8
+	// secretBytes is the randomness of the client secrets and the refresh tokens, enough for a fast hash
+	secretBytes = 32
+	// maxFormBytes caps the body of the requests, the forms only carry a few credentials
+	maxFormBytes = 1 << 16
+	// pruneInterval is how often the expired authorization codes are deleted
+	pruneInterval = time.Hour
+)
+
+// GrantTypes are the grant types a client can be registered with
+var GrantTypes = []string{GrantAuthorizationCode, GrantClientCredentials, GrantRefreshToken}
+
+type (
+	// Config is the [oauth] section of the configuration
+	Config struct {
+		// AccessTokenLifetime is how long the access tokens are valid, a duration like "1h"
+		AccessTokenLifetime time.Duration `mapstructure:"access_token_lifetime"`
+		// RefreshTokenLifetime is how long the refresh tokens are valid, a duration like "720h". A refreshed token is
+		// valid for the whole lifetime again.
+		RefreshTokenLifetime time.Duration `mapstructure:"refresh_token_lifetime"`
+		// Issuer is the URL the clients reach the service at, the iss of the ID tokens. The endpoints of the discovery
+		// document are below it.
+		Issuer string `mapstructure:"issuer"`
+		// SigningKeyFile is the PEM RSA private key signing the ID tokens, an ephemeral key is generated when empty. It
+		// is required once a client has the authorization_code grant.
+		SigningKeyFile string `mapstructure:"signing_key_file"`
+	}
+
+	// Server serves the OAuth2 endpoints and the OpenID Connect provider, the access tokens are the JWTs of the
+	// authenticator and their sessions are kept in its session registry like the ones of the login page
+	Server struct {
+		db                   *sql.DB
+		authenticator        *auth.JWT
+		accessTokenLifetime  time.Duration
+		refreshTokenLifetime time.Duration
+		issuer               string
+		key                  *signingKey
+		// secureCookies is set when the issuer is served over TLS
+		secureCookies bool
+		// limiter limits the requests carrying credentials, they are not served by the gRPC interceptors
+		limiter *ratelimit.Limiter
+	}
+
+	// oauthError is the error response of RFC 6749
+	oauthError struct {
+		Code        string `json:"error"`
+		Description string `json:"error_description,omitempty"`
+		status      int
+		// retryAfter is set for the requests over the rate limit
+		retryAfter time.Duration
+	}
+)
+
+var (
+	errMethodNotAllowed = &oauthError{Code: "invalid_request", Description: "the method must be POST", status: http.StatusMethodNotAllowed}
+	errInvalidClient    = &oauthError{Code: "invalid_client", Description: "client authentication failed", status: http.StatusUnauthorized}
+	errInvalidGrant     = &oauthError{Code: "invalid_grant", Description: "the refresh token is invalid, expired or revoked", status: http.StatusBadRequest}
+	errInvalidCode      = &oauthError{Code: "invalid_grant", Description: "the authorization code is invalid, expired or already used", status: http.StatusBadRequest}
+	errServerError      = &oauthError{Code: "server_error", status: http.StatusInternalServerError}
+)
+
+// LoadConfig reads and validates the [oauth] section of the configuration
+func LoadConfig() (Config, error) {
+	c := Config{
+		AccessTokenLifetime:  DefaultAccessTokenLifetime,
+		RefreshTokenLifetime: DefaultRefreshTokenLifetime,
+		Issuer:               DefaultIssuer,
+	}
+	if err := viper.UnmarshalKey("oauth", &c); err != nil {
+		return c, errors.Wrap(err, "cannot read oauth")
+	}
+	// the numbers without a unit are nanoseconds
+	if c.AccessTokenLifetime < time.Second || c.RefreshTokenLifetime < time.Second {
+		return c, errors.New(`oauth.access_token_lifetime and oauth.refresh_token_lifetime must be durations of at ` +
+			`least a second, like "1h"`)
+	}
+	if c.AccessTokenLifetime > time.Duration(auth.DefaultLifetime)*time.Hour {
+		return c, errors.Errorf("oauth.access_token_lifetime cannot exceed the %d hours of the session registry",
+			auth.DefaultLifetime)
+	}
+	issuer, err := url.Parse(c.Issuer)
+	if err != nil || (issuer.Scheme != "http" && issuer.Scheme != "https") || len(issuer.Host) == 0 ||
+		len(issuer.RawQuery) > 0 || len(issuer.Fragment) > 0 {
+		return c, errors.Errorf("oauth.issuer %q must be an http or https URL without a query", c.Issuer)
+	}
+	c.Issuer = strings.TrimSuffix(c.Issuer, "/")
+	return c, nil
+}
+
+// NewServer is the constructor for the Server, it loads the signing key of the ID tokens. It fails without a signing
+// key file when a client has the authorization_code grant, the ID tokens signed with an ephemeral key cannot be
+// verified after a restart nor by the other nodes. The limiter applies the rules of the paths.
+func NewServer(c Config, db *sql.DB, authenticator *auth.JWT, limiter *ratelimit.Limiter) (*Server, error) {
+	if len(c.SigningKeyFile) == 0 && db != nil {
+		if err := requireNoAuthorizationCodeClient(db); err != nil {
+			return nil, err
+		}
+	}
+	key, err := loadSigningKey(c.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		db:                   db,
+		authenticator:        authenticator,
+		accessTokenLifetime:  c.AccessTokenLifetime,
+		refreshTokenLifetime: c.RefreshTokenLifetime,
+		issuer:               c.Issuer,
+		key:                  key,
+		secureCookies:        strings.HasPrefix(c.Issuer, "https://"),
+		limiter:              limiter,
+	}, nil
+}
+
+// requireNoAuthorizationCodeClient fails when a client has the authorization_code grant or when the clients cannot be
+// listed
+func requireNoAuthorizationCodeClient(db *sql.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clients, err := query.ListOAuthClients(ctx, db)
+	if err != nil {
+		return errors.WithMessage(err, "cannot check the grants of the OAuth2 clients without oauth.signing_key_file")
+	}
+	for _, client := range clients {
+		if contains(client.GrantTypes, GrantAuthorizationCode) {
+			return errors.Errorf("oauth.signing_key_file is required, the client %s has the authorization_code grant",
+				client.ID)
+		}
+	}
+	return nil
+}
+
+// Register adds the endpoints to the mux
+func (s *Server) Register(mux *http.ServeMux) {
+	mux.Handle(TokenPath, post(s.token))
+	mux.Handle(IntrospectPath, post(s.introspect))
+	mux.Handle(RevokePath, post(s.revoke))
+	mux.HandleFunc(AuthorizePath, s.authorize)
+	mux.HandleFunc(UserInfoPath, s.userInfo)
+	mux.HandleFunc(JWKSPath, s.jwks)
+	mux.HandleFunc(DiscoveryPath, s.discovery)
+}
+
+// Run deletes the expired authorization codes until the context is canceled
+func (s *Server) Run(ctx context.Context) error {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// a pass is not interrupted by the shutdown
+			if _, err := query.DeleteExpiredAuthorizationCodes(context.Background(), s.db, time.Now()); err != nil {
+				logging.Ctx(ctx).Error().Err(err).Msg("cannot delete the expired authorization codes")
+			}
+		}
+	}
+}
+
+// Subscribe revokes the refresh tokens of the users that are deleted, erased or whose password is changed, the
+// authenticator evicts the sessions of their access tokens
+func Subscribe(bus *events.Bus) {
+	bus.Subscribe(func(ctx context.Context, exec boil.ContextExecutor, event events.Event) error {
+		return query.RevokeUserRefreshTokens(ctx, exec, event.UserID, time.Now())
+	}, events.UserDeleted, events.UserErased, events.PasswordChanged)
+}
+
+// NewClient returns a client to insert and its secret, which is only shown once since only its sha256 is stored
+func NewClient(name string, serviceAccountID string, grantTypes, scopes, redirectURIs []string) (*models.OauthClient, string, error) {
+	id, err := random(ClientIDPrefix, clientIDBytes)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "cannot generate the client ID")
+	}
+	secret, err := random(ClientSecretPrefix, secretBytes)
+	if err != nil {
+		return nil, "", errors.WithMessage(err, "cannot generate the client secret")
+	}
+
+	client := &models.OauthClient{
+		ID:           id,
+		Name:         name,
+		SecretHash:   hash(secret),
+		GrantTypes:   grantTypes,
+		Scopes:       scopes,
+		RedirectUris: redirectURIs,
+		CreatedAt:    time.Now(),
+	}
+	if len(serviceAccountID) > 0 {
+		client.ServiceAccountID.SetValid(serviceAccountID)
+	}
+	return client, secret, nil
+}
+
+// post only lets the POST requests with a bounded form through, the responses are never cached
+func post(handler func(r *http.Request) (interface{}, *oauthError)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Pragma", "no-cache")
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeJSON(r.Context(), w, errMethodNotAllowed.status, errMethodNotAllowed)
+			return
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		if err := r.ParseForm(); err != nil {
+			writeJSON(r.Context(), w, http.StatusBadRequest, invalidRequest("the body must be a form"))
+			return
+		}
+
+		res, oauthErr := handler(r)
+		if oauthErr != nil {
+			if oauthErr == errInvalidClient {
+				w.Header().Set("WWW-Authenticate", `Basic realm="user.app"`)
+			}
+			if oauthErr.retryAfter > 0 {
+				setRetryAfter(w, oauthErr.retryAfter)
+			}
+			writeJSON(r.Context(), w, oauthErr.status, oauthErr)
+			return
+		}
+		writeJSON(r.Context(), w, http.StatusOK, res)
+	})
+}
+
+func writeJSON(ctx context.Context, w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot write the OAuth2 response")
+	}
+}
+
+// Error lets the errors of the responses abort the transactions
+func (e *oauthError) Error() string {
+	return e.Code
+}
+
+func invalidRequest(description string) *oauthError {
+	return &oauthError{Code: "invalid_request", Description: description, status: http.StatusBadRequest}
+}
+
+// wait takes a token from the rate limit buckets of the request, the account is the username or the client_id it
+// presents credentials for. It returns how long until the request would be allowed, 0 when it is.
+func (s *Server) wait(r *http.Request, account string) time.Duration {
+	return s.limiter.Wait(r.Context(), r.URL.Path, ratelimit.Client{IP: transport.HTTPClientIP(r), Account: account})
+}
+
+// setRetryAfter tells the client when its next request is allowed, in whole seconds
+func setRetryAfter(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+}
+
+// authenticateClient authenticates the client with HTTP Basic or with the client_id and client_secret of the form,
+// a revoked client is rejected
+func (s *Server) authenticateClient(r *http.Request) (*models.OauthClient, *oauthError) {
+	id, secret, basic := r.BasicAuth()
+	if basic {
+		if len(r.PostForm.Get("client_secret")) > 0 {
+			return nil, invalidRequest("the client must use a single authentication method")
+		}
+		// the credentials are form encoded before being put in the header
+		var err error
+		if id, err = url.QueryUnescape(id); err != nil {
+			return nil, errInvalidClient
+		}
+		if secret, err = url.QueryUnescape(secret); err != nil {
+			return nil, errInvalidClient
+		}
+	} else {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if wait := s.wait(r, id); wait > 0 {
+		return nil, &oauthError{
+			Code:        "temporarily_unavailable",
+			Description: "rate limit exceeded",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  wait,
+		}
+	}
+	if len(id) == 0 || len(secret) == 0 {
+		return nil, errInvalidClient
+	}
+
+	client, err := query.FindOAuthClient(r.Context(), s.db, id)
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return nil, errInvalidClient
+		}
+		logging.Ctx(r.Context()).Error().Err(err).Msg("cannot retrieve the OAuth2 client")
+		return nil, errServerError
+	}
+	if subtle.ConstantTimeCompare(hash(secret), client.SecretHash) != 1 || client.RevokedAt.Valid {
+		return nil, errInvalidClient
+	}
+	return client, nil
+}
+
+// grantedScopes returns the scopes of the space delimited scope parameter, all the allowed ones when it is empty. It
+// fails when one of them is not allowed.
+func grantedScopes(scope string, allowed []string) ([]string, *oauthError) {
+	requested := strings.Fields(scope)
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+
+	var scopes []string
+	for _, s := range requested {
+		if !contains(allowed, s) {
+			return nil, &oauthError{
+				Code:        "invalid_scope",
+				Description: "the scope " + s + " is not granted to the client",
+				status:      http.StatusBadRequest,
+			}
+		}
+		if !contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// random returns the prefix followed by n random bytes in hex
+func random(prefix string, n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
+
+// hash returns the sha256 of a client secret or a refresh token, they are random enough for a fast hash
+func hash(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
@@ -0,0 +1,158 @@
+package oauth
+
+import (
+	"encoding/json"
+	"github.com/spf13/viper"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"user.app/pkg/ratelimit"
+)
+
+func TestGrantedScopes(t *testing.T) {
+	allowed := []string{"read", "write"}
+	tests := []struct {
+		scope   string
+		want    []string
+		invalid bool
+	}{
+		{scope: "", want: allowed},
+		{scope: "read", want: []string{"read"}},
+		{scope: " write  read write ", want: []string{"write", "read"}},
+		{scope: "read admin", invalid: true},
+	}
+	for _, tt := range tests {
+		scopes, oauthErr := grantedScopes(tt.scope, allowed)
+		if tt.invalid {
+			if oauthErr == nil || oauthErr.Code != "invalid_scope" {
+				t.Errorf("grantedScopes(%q) error = %v, want invalid_scope", tt.scope, oauthErr)
+			}
+			continue
+		}
+		if oauthErr != nil || !reflect.DeepEqual(scopes, tt.want) {
+			t.Errorf("grantedScopes(%q) = %v, %v, want %v", tt.scope, scopes, oauthErr, tt.want)
+		}
+	}
+}
+
+func TestEndpointsRejectBadRequests(t *testing.T) {
+	config := Config{AccessTokenLifetime: time.Minute, RefreshTokenLifetime: time.Hour, Issuer: DefaultIssuer}
+	server, err := NewServer(config, nil, nil, ratelimit.NewLimiter(nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server.Register(mux)
+
+	form := func(values url.Values) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+	basic := form(url.Values{"grant_type": {"client_credentials"}, "client_secret": {"uas_x"}})
+	basic.SetBasicAuth("uac_x", "uas_x")
+
+	tests := []struct {
+		name   string
+		r      *http.Request
+		status int
+		code   string
+	}{
+		{"get", httptest.NewRequest(http.MethodGet, TokenPath, nil), http.StatusMethodNotAllowed, "invalid_request"},
+		{"no client", form(url.Values{"grant_type": {"client_credentials"}}), http.StatusUnauthorized, "invalid_client"},
+		{"no secret", form(url.Values{"client_id": {"uac_x"}}), http.StatusUnauthorized, "invalid_client"},
+		{"two methods", basic, http.StatusBadRequest, "invalid_request"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, tt.r)
+
+		var res oauthError
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatalf("%s: cannot decode the response: %v", tt.name, err)
+		}
+		if w.Code != tt.status || res.Code != tt.code {
+			t.Errorf("%s: got %d %s, want %d %s", tt.name, w.Code, res.Code, tt.status, tt.code)
+		}
+		if w.Header().Get("Cache-Control") != "no-store" {
+			t.Errorf("%s: the response can be cached", tt.name)
+		}
+		if tt.code == "invalid_client" && len(w.Header().Get("WWW-Authenticate")) == 0 {
+			t.Errorf("%s: WWW-Authenticate is missing", tt.name)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		access, refresh interface{}
+		valid           bool
+	}{
+		{"1h", "720h", true},
+		{"90s", "24h", true},
+		{"72h", "1h", true},
+		{"73h", "720h", false},
+		{3600, "720h", false},
+		{"1h", 720, false},
+		{"0s", "720h", false},
+		{"1h", "-1h", false},
+	}
+	defer viper.Set("oauth", nil)
+	for _, tt := range tests {
+		viper.Set("oauth", map[string]interface{}{"access_token_lifetime": tt.access, "refresh_token_lifetime": tt.refresh})
+		c, err := LoadConfig()
+		if (err == nil) != tt.valid {
+			t.Errorf("%v, %v: got %v, want valid %v", tt.access, tt.refresh, err, tt.valid)
+		}
+		if err == nil && (c.AccessTokenLifetime < time.Second || c.RefreshTokenLifetime < time.Second) {
+			t.Errorf("%v, %v: got %s, %s", tt.access, tt.refresh, c.AccessTokenLifetime, c.RefreshTokenLifetime)
+		}
+	}
+}
+
+func TestTokenEndpointIsRateLimited(t *testing.T) {
+	limiter := ratelimit.NewLimiter([]ratelimit.Rule{
+		{Method: TokenPath, Rate: 0.1, Burst: 3, Key: ratelimit.KeyPeer},
+		{Method: TokenPath, Rate: 0.1, Burst: 1, Key: ratelimit.KeyAccount},
+	}, ratelimit.NewMemoryStore())
+	config := Config{AccessTokenLifetime: time.Minute, RefreshTokenLifetime: time.Hour, Issuer: DefaultIssuer}
+	server, err := NewServer(config, nil, nil, limiter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	server.Register(mux)
+
+	// the requests have no secret, they fail before the client is looked up
+	tests := []struct {
+		clientID   string
+		remoteAddr string
+		status     int
+	}{
+		{"uac_x", "192.0.2.1:40000", http.StatusUnauthorized},
+		{"uac_x", "192.0.2.2:40000", http.StatusTooManyRequests},
+		{"uac_y", "192.0.2.1:40001", http.StatusUnauthorized},
+		{"uac_z", "192.0.2.1:40002", http.StatusUnauthorized},
+		{"uac_w", "192.0.2.1:40003", http.StatusTooManyRequests},
+		{"uac_v", "192.0.2.3:40000", http.StatusUnauthorized},
+	}
+	for i, tt := range tests {
+		body := url.Values{"client_id": {tt.clientID}}.Encode()
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.RemoteAddr = tt.remoteAddr
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+
+		if w.Code != tt.status {
+			t.Errorf("request %d of %s from %s: got %d, want %d", i, tt.clientID, tt.remoteAddr, w.Code, tt.status)
+		}
+		if retryAfter := w.Header().Get("Retry-After"); (tt.status == http.StatusTooManyRequests) != (retryAfter == "10") {
+			t.Errorf("request %d: got Retry-After %q", i, retryAfter)
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/logging"
+	"user.app/pkg/query"
+)
+
+const (
+	// DiscoveryPath and the other paths of the OpenID Connect provider are served next to the OAuth2 endpoints
+	DiscoveryPath = "/.well-known/openid-configuration"
+	JWKSPath      = "/oauth/jwks"
+	UserInfoPath  = "/oauth/userinfo"
+
+	// ScopeOpenID and the other scopes of the OpenID Connect provider can be granted to the clients next to the scopes
+	// of the API, openid is required by the authorization endpoint
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+
+	// signingKeyBits is the size of the ephemeral key generated when no signing key is configured
+	signingKeyBits = 2048
+)
+
+// OIDCScopes are the scopes of the OpenID Connect provider
+var OIDCScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail}
+
+type (
+	// signingKey signs the ID tokens, the clients find its public key by its ID in the JWKS
+	signingKey struct {
+		id      string
+		private *rsa.PrivateKey
+	}
+
+	// idTokenClaims are the claims of the ID tokens, the profile and email ones are only set for their scopes
+	idTokenClaims struct {
+		Nonce             string `json:"nonce,omitempty"`
+		AuthTime          int64  `json:"auth_time,omitempty"`
+		PreferredUsername string `json:"preferred_username,omitempty"`
+		Email             string `json:"email,omitempty"`
+		jwt.StandardClaims
+	}
+
+	// userInfoResponse is the response of the userinfo endpoint, with the claims of the scopes of the access token
+	userInfoResponse struct {
+		Subject           string `json:"sub"`
+		PreferredUsername string `json:"preferred_username,omitempty"`
+		Email             string `json:"email,omitempty"`
+	}
+
+	// jwk is the public part of the signing key
+	jwk struct {
+		KeyType   string `json:"kty"`
+		Use       string `json:"use"`
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+		Modulus   string `json:"n"`
+		Exponent  string `json:"e"`
+	}
+)
+
+// loadSigningKey reads the PEM RSA private key, PKCS #1 or PKCS #8, or generates an ephemeral key when there is no
+// file. The ID tokens signed with an ephemeral key cannot be verified once the server restarts, nor by the other nodes.
+func loadSigningKey(file string) (*signingKey, error) {
+	var (
+		key *rsa.PrivateKey
+		err error
+	)
+	if len(file) == 0 {
+		log.Warn().Msg("oauth.signing_key_file is not set, the ID tokens are signed with an ephemeral key and cannot " +
+			"be verified after a restart nor by the other nodes, set it before registering an authorization_code client")
+		if key, err = rsa.GenerateKey(rand.Reader, signingKeyBits); err != nil {
+			return nil, errors.Wrap(err, "cannot generate the signing key")
+		}
+	} else {
+		var pemBytes []byte
+		if pemBytes, err = ioutil.ReadFile(file); err != nil {
+			return nil, errors.Wrap(err, "cannot read oauth.signing_key_file")
+		}
+		block, _ := pem.Decode(pemBytes)
+		if block == nil {
+			return nil, errors.New("oauth.signing_key_file is not a PEM file")
+		}
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			var parsed interface{}
+			if parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+				var ok bool
+				if key, ok = parsed.(*rsa.PrivateKey); !ok {
+					err = errors.New("not an RSA key")
+				}
+			}
+		default:
+			err = errors.Errorf("unsupported PEM block %q", block.Type)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse oauth.signing_key_file")
+		}
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal the public key")
+	}
+	sum := sha256.Sum256(der)
+	return &signingKey{
+		id:      base64.RawURLEncoding.EncodeToString(sum[:12]),
+		private: key,
+	}, nil
+}
+
+// idToken returns the ID token of the user for the client, empty when the openid scope has not been granted
+func (s *Server) idToken(user *models.User, clientID string, scopes []string, nonce string, authTime time.Time) (string, error) {
+	if !contains(scopes, ScopeOpenID) {
+		return "", nil
+	}
+
+	now := time.Now()
+	claims := idTokenClaims{
+		Nonce: nonce,
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    s.issuer,
+			Subject:   user.ID,
+			Audience:  clientID,
+			ExpiresAt: now.Add(s.accessTokenLifetime).Unix(),
+			IssuedAt:  now.Unix(),
+		},
+	}
+	if !authTime.IsZero() {
+		claims.AuthTime = authTime.Unix()
+	}
+	if contains(scopes, ScopeProfile) {
+		claims.PreferredUsername = user.Username
+	}
+	if contains(scopes, ScopeEmail) {
+		claims.Email = user.Email
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.key.id
+	idToken, err := token.SignedString(s.key.private)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot sign the ID token")
+	}
+	return idToken, nil
+}
+
+// discovery serves the OpenID Connect discovery document
+func (s *Server) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(r.Context(), w, http.StatusOK, map[string]interface{}{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + AuthorizePath,
+		"token_endpoint":                        s.issuer + TokenPath,
+		"userinfo_endpoint":                     s.issuer + UserInfoPath,
+		"jwks_uri":                              s.issuer + JWKSPath,
+		"introspection_endpoint":                s.issuer + IntrospectPath,
+		"revocation_endpoint":                   s.issuer + RevokePath,
+		"response_types_supported":              []string{"code"},
+		"response_modes_supported":              []string{"query"},
+		"grant_types_supported":                 GrantTypes,
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      append(append([]string{}, OIDCScopes...), auth.Scopes...),
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{codeChallengeS256},
+		"claims_supported": []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "preferred_username", "email",
+		},
+	})
+}
+
+// jwks serves the public key of the ID tokens
+func (s *Server) jwks(w http.ResponseWriter, r *http.Request) {
+	public := s.key.private.PublicKey
+	writeJSON(r.Context(), w, http.StatusOK, map[string][]jwk{
+		"keys": {{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: "RS256",
+			KeyID:     s.key.id,
+			Modulus:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}},
+	})
+}
+
+// userInfo returns the claims of the user of an access token having the openid scope
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := r.Header.Get("Authorization")
+	if len(token) < len("bearer ") || !strings.EqualFold(token[:len("bearer ")], "bearer ") {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="user.app"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	invalidToken := func() {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="user.app", error="invalid_token"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+
+	claims, session, err := s.authenticator.IntrospectToken(ctx, token[len("bearer "):])
+	if err != nil {
+		if err == auth.ErrInvalidCredentialsError {
+			invalidToken()
+			return
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot introspect the access token")
+		writeJSON(ctx, w, errServerError.status, errServerError)
+		return
+	}
+	if session.ClaimType != auth.OAuth2 || !contains(session.Scopes, ScopeOpenID) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="user.app", error="insufficient_scope", scope="openid"`)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	user, err := query.FindUser(ctx, s.db, &query.UserFilter{ID: claims.ID})
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			invalidToken()
+			return
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the user of the access token")
+		writeJSON(ctx, w, errServerError.status, errServerError)
+		return
+	}
+
+	res := userInfoResponse{Subject: user.ID}
+	if contains(session.Scopes, ScopeProfile) {
+		res.PreferredUsername = user.Username
+	}
+	if contains(session.Scopes, ScopeEmail) {
+		res.Email = user.Email
+	}
+	writeJSON(ctx, w, http.StatusOK, res)
+}
@@ -0,0 +1,331 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/coreos/go-oidc"
+	"github.com/spf13/viper"
+	"golang.org/x/oauth2"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/conn"
+	"user.app/pkg/query"
+	"user.app/pkg/ratelimit"
+)
+
+// newTestProvider serves the endpoints on a test server which is the issuer, the ID tokens are signed with a key file
+// since the database may have authorization_code clients
+func newTestProvider(t *testing.T) (*Server, *httptest.Server) {
+	key, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile, err := ioutil.TempFile("", "signing-key-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(keyFile.Name())
+	err = pem.Encode(keyFile, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if closeErr := keyFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	ts := httptest.NewServer(mux)
+	config := Config{
+		AccessTokenLifetime:  time.Minute,
+		RefreshTokenLifetime: time.Hour,
+		Issuer:               ts.URL,
+		SigningKeyFile:       keyFile.Name(),
+	}
+	server, err := NewServer(config, conn.Instance, auth.Authenticator, ratelimit.NewLimiter(nil, nil))
+	if err != nil {
+		ts.Close()
+		t.Fatal(err)
+	}
+	server.Register(mux)
+	return server, ts
+}
+
+func TestIDToken(t *testing.T) {
+	server, ts := newTestProvider(t)
+	defer ts.Close()
+	ctx := context.Background()
+	provider, err := oidc.NewProvider(ctx, ts.URL)
+	if err != nil {
+		t.Fatalf("cannot discover the provider: %v", err)
+	}
+
+	user := &models.User{ID: "8a1c7f3e-0d5e-4a5b-9a4e-2f1c6a0b7d11", Username: "alice", Email: "alice@example.com"}
+	authTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	raw, err := server.idToken(user, "uac_test", []string{ScopeOpenID, ScopeProfile}, "n-0S6_WzA2Mj", authTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: "uac_test"}).Verify(ctx, raw)
+	if err != nil {
+		t.Fatalf("the ID token does not verify: %v", err)
+	}
+	var claims idTokenClaims
+	if err = idToken.Claims(&claims); err != nil {
+		t.Fatal(err)
+	}
+	if idToken.Subject != user.ID || idToken.Nonce != "n-0S6_WzA2Mj" || claims.AuthTime != authTime.Unix() {
+		t.Errorf("got sub %s, nonce %s, auth_time %d", idToken.Subject, idToken.Nonce, claims.AuthTime)
+	}
+	if claims.PreferredUsername != "alice" || len(claims.Email) > 0 {
+		t.Errorf("got preferred_username %q and email %q, want the profile scope only", claims.PreferredUsername,
+			claims.Email)
+	}
+
+	if _, err = provider.Verifier(&oidc.Config{ClientID: "uac_other"}).Verify(ctx, raw); err == nil {
+		t.Error("the ID token verifies for another client")
+	}
+	if raw, err = server.idToken(user, "uac_test", []string{ScopeProfile}, "", time.Time{}); err != nil || len(raw) > 0 {
+		t.Errorf("got an ID token %q, %v without the openid scope", raw, err)
+	}
+}
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	verifier := "x3fmFvBeHSE1u2tkz3aV4nLkVQ8pa0pnOWNRyZtXkqg"
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	tests := []struct {
+		verifier  string
+		challenge string
+		want      bool
+	}{
+		{verifier, challenge, true},
+		{verifier + "A", challenge, false},
+		{verifier, verifier, false},
+		{"short", "short", false},
+	}
+	for _, tt := range tests {
+		if got := verifyCodeChallenge(tt.verifier, tt.challenge); got != tt.want {
+			t.Errorf("verifyCodeChallenge(%q, %q) = %v, want %v", tt.verifier, tt.challenge, got, tt.want)
+		}
+	}
+}
+
+// TestAuthorizationCodeFlow signs a user in with an OpenID Connect client against CockroachDB, it is skipped when the
+// database of configs/config.toml is not reachable
+func TestAuthorizationCodeFlow(t *testing.T) {
+	viper.SetConfigFile("../../configs/config.toml")
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	instance, err := conn.InitDBConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err = instance.PingContext(ctx); err != nil {
+		t.Skipf("CockroachDB is not reachable: %v", err)
+	}
+	conn.Instance = instance
+	if auth.Authenticator, err = auth.NewJWTAuth(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ts := newTestProvider(t)
+	defer ts.Close()
+	const redirectURL = "https://client.example/callback"
+	username, _ := random("oidc", 6)
+	password, _ := random("", 16)
+	hashed, err := auth.HashPassword(ctx, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &models.User{Username: username, Email: username + "@example.com", Password: hashed}
+	if err = query.CreateUser(ctx, instance, user); err != nil {
+		t.Fatal(err)
+	}
+	client, secret, err := NewClient("test", "", []string{GrantAuthorizationCode, GrantRefreshToken},
+		[]string{ScopeOpenID, ScopeProfile, ScopeEmail}, []string{redirectURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = query.CreateOAuthClient(ctx, instance, client); err != nil {
+		t.Fatal(err)
+	}
+	other, otherSecret, err := NewClient("other", "", []string{GrantAuthorizationCode}, []string{ScopeOpenID},
+		[]string{redirectURL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = query.CreateOAuthClient(ctx, instance, other); err != nil {
+		t.Fatal(err)
+	}
+	// the codes, the consents and the refresh tokens are deleted in cascade
+	defer func() {
+		if _, err := instance.Exec("DELETE FROM oauth_clients WHERE id IN ($1, $2)", client.ID, other.ID); err != nil {
+			t.Error(err)
+		}
+		if _, err := instance.Exec("DELETE FROM users WHERE id = $1", user.ID); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	provider, err := oidc.NewProvider(ctx, ts.URL)
+	if err != nil {
+		t.Fatalf("cannot discover the provider: %v", err)
+	}
+	config := oauth2.Config{
+		ClientID:     client.ID,
+		ClientSecret: secret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  redirectURL,
+		Scopes:       []string{oidc.ScopeOpenID, ScopeProfile, ScopeEmail},
+	}
+	verifier, _ := random("", 32)
+	sum := sha256.Sum256([]byte(verifier))
+	authCodeURL := func(state, nonce string) string {
+		return config.AuthCodeURL(state, oidc.Nonce(nonce),
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(sum[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", codeChallengeS256))
+	}
+
+	jar, _ := cookiejar.New(nil)
+	browser := &http.Client{
+		Jar:           jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	// redirected returns the parameters the browser is redirected to the client with
+	redirected := func(res *http.Response) url.Values {
+		t.Helper()
+		defer res.Body.Close()
+		location, err := res.Location()
+		if res.StatusCode != http.StatusFound || err != nil || !strings.HasPrefix(location.String(), redirectURL) {
+			body, _ := ioutil.ReadAll(res.Body)
+			t.Fatalf("got %d to %v, want a redirection to the client: %s", res.StatusCode, location, body)
+		}
+		return location.Query()
+	}
+
+	// the login page signs the user in and asks for the consent
+	authURL := authCodeURL("state-1", "nonce-1")
+	res, err := browser.Get(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	csrf := regexp.MustCompile(`name="csrf" value="([0-9a-f]+)"`).FindSubmatch(body)
+	if res.StatusCode != http.StatusOK || csrf == nil || !strings.Contains(string(body), `name="password"`) {
+		t.Fatalf("got %d, want the login page: %s", res.StatusCode, body)
+	}
+	parsed, _ := url.Parse(authURL)
+	form := parsed.Query()
+	form.Set("csrf", string(csrf[1]))
+	form.Set("username", username)
+	form.Set("password", password)
+	form.Set("action", "allow")
+	res, err = browser.PostForm(ts.URL+AuthorizePath, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := redirected(res)
+	if params.Get("state") != "state-1" || len(params.Get("code")) == 0 {
+		t.Fatalf("got %v, want a code and the state", params)
+	}
+	code := params.Get("code")
+
+	token, err := config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		t.Fatalf("cannot exchange the code: %v", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: client.ID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		t.Fatalf("the ID token does not verify: %v", err)
+	}
+	if idToken.Subject != user.ID || idToken.Nonce != "nonce-1" {
+		t.Errorf("got sub %s and nonce %s, want %s and nonce-1", idToken.Subject, idToken.Nonce, user.ID)
+	}
+	userInfo, err := provider.UserInfo(ctx, config.TokenSource(ctx, token))
+	if err != nil {
+		t.Fatalf("cannot get the user info: %v", err)
+	}
+	if userInfo.Subject != user.ID || userInfo.Email != user.Email {
+		t.Errorf("got the user info of %s %s, want %s %s", userInfo.Subject, userInfo.Email, user.ID, user.Email)
+	}
+
+	// the tokens are only active to the client they were issued to
+	active := func(clientID, clientSecret, token string) bool {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+IntrospectPath, strings.NewReader(url.Values{
+			"token": {token},
+		}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(clientID, clientSecret)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		var introspection introspectionResponse
+		if err = json.NewDecoder(res.Body).Decode(&introspection); err != nil || res.StatusCode != http.StatusOK {
+			t.Fatalf("got %d and %v, want an introspection", res.StatusCode, err)
+		}
+		return introspection.Active
+	}
+	for _, issued := range []string{token.AccessToken, token.RefreshToken} {
+		if !active(client.ID, secret, issued) {
+			t.Error("a token is inactive to its client")
+		}
+		if active(other.ID, otherSecret, issued) {
+			t.Error("a token is active to another client")
+		}
+	}
+
+	// the browser is signed in and the user has consented, the code is issued at once
+	res, err = browser.Get(authCodeURL("state-2", "nonce-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params = redirected(res); params.Get("state") != "state-2" || len(params.Get("code")) == 0 {
+		t.Fatalf("got %v, want a code without the login page", params)
+	}
+	if _, err = config.Exchange(ctx, params.Get("code")); err == nil {
+		t.Error("a code was exchanged without its code verifier")
+	}
+
+	// a replayed code is rejected and the tokens issued for it are revoked
+	if _, err = config.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier)); err == nil {
+		t.Error("a code was exchanged twice")
+	}
+	if _, err = provider.UserInfo(ctx, oauth2.StaticTokenSource(token)); err == nil {
+		t.Error("the access token of a replayed code is still valid")
+	}
+
+	// a browser that is not signed in cannot get a code without the login page
+	res, err = (&http.Client{CheckRedirect: browser.CheckRedirect}).Get(authCodeURL("state-3", "") + "&prompt=none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if params = redirected(res); params.Get("error") != "login_required" || params.Get("state") != "state-3" {
+		t.Errorf("got %v, want login_required", params)
+	}
+}
@@ -0,0 +1,438 @@
+package oauth
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+	"time"
+	"user.app/models"
+	"user.app/pkg/auth"
+	"user.app/pkg/conn"
+	"user.app/pkg/logging"
+	"user.app/pkg/query"
+)
+
+type (
+	// tokenResponse is the access token response of RFC 6749
+	tokenResponse struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+		RefreshToken string `json:"refresh_token,omitempty"`
+		Scope        string `json:"scope,omitempty"`
+		IDToken      string `json:"id_token,omitempty"`
+
+		// sessionID is evicted when the transaction issuing the tokens is rolled back
+		sessionID string
+	}
+
+	// introspectionResponse is the introspection response of RFC 7662, only active is sent for the inactive tokens
+	introspectionResponse struct {
+		Active    bool   `json:"active"`
+		Scope     string `json:"scope,omitempty"`
+		ClientID  string `json:"client_id,omitempty"`
+		Username  string `json:"username,omitempty"`
+		Subject   string `json:"sub,omitempty"`
+		ExpiresAt int64  `json:"exp,omitempty"`
+		IssuedAt  int64  `json:"iat,omitempty"`
+		TokenType string `json:"token_type,omitempty"`
+	}
+)
+
+// token issues the access tokens of the authorization_code, client_credentials and refresh_token grants
+func (s *Server) token(r *http.Request) (interface{}, *oauthError) {
+	client, oauthErr := s.authenticateClient(r)
+	if oauthErr != nil {
+		return nil, oauthErr
+	}
+
+	grantType := r.PostForm.Get("grant_type")
+	if len(grantType) == 0 {
+		return nil, invalidRequest("grant_type is missing")
+	}
+	if !contains(GrantTypes, grantType) {
+		return nil, &oauthError{Code: "unsupported_grant_type", status: http.StatusBadRequest}
+	}
+	if !contains(client.GrantTypes, grantType) {
+		return nil, &oauthError{
+			Code:        "unauthorized_client",
+			Description: "the client is not registered for the " + grantType + " grant",
+			status:      http.StatusBadRequest,
+		}
+	}
+
+	switch grantType {
+	case GrantAuthorizationCode:
+		return s.authorizationCode(r, client)
+	case GrantRefreshToken:
+		return s.refresh(r, client)
+	default:
+		return s.clientCredentials(r, client)
+	}
+}
+
+// authorizationCode exchanges an authorization code for the tokens of its user, the code verifier must match its PKCE
+// challenge. A code is used once, the tokens issued for it are revoked when it is replayed.
+func (s *Server) authorizationCode(r *http.Request, client *models.OauthClient) (interface{}, *oauthError) {
+	ctx := r.Context()
+	code := r.PostForm.Get("code")
+	if len(code) == 0 {
+		return nil, invalidRequest("code is missing")
+	}
+	verifier := r.PostForm.Get("code_verifier")
+	if !pkceValue.MatchString(verifier) {
+		return nil, invalidRequest("code_verifier is missing or malformed")
+	}
+
+	var (
+		res      *tokenResponse
+		replayed string
+	)
+	err := conn.ExecuteTx(ctx, s.db, func(tx *sql.Tx) error {
+		s.discard(ctx, res)
+		res, replayed = nil, ""
+
+		authorizationCode, err := query.FindAuthorizationCodeByHash(ctx, tx, hash(code))
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return errInvalidCode
+			}
+			return err
+		}
+		if authorizationCode.ClientID != client.ID {
+			return errInvalidCode
+		}
+		if authorizationCode.UsedAt.Valid {
+			replayed = authorizationCode.SessionID.String
+			return nil
+		}
+		now := time.Now()
+		if !authorizationCode.ExpiresAt.After(now) ||
+			authorizationCode.RedirectURI != r.PostForm.Get("redirect_uri") ||
+			!verifyCodeChallenge(verifier, authorizationCode.CodeChallenge) {
+			return errInvalidCode
+		}
+
+		user, err := query.FindUser(ctx, tx, &query.UserFilter{ID: authorizationCode.UserID})
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return errInvalidCode
+			}
+			return err
+		}
+		if res, err = s.issue(ctx, tx, client, user, authorizationCode.Scopes, true); err != nil {
+			return err
+		}
+		if err = query.UseAuthorizationCode(ctx, tx, authorizationCode, res.sessionID, now); err != nil {
+			return err
+		}
+		res.IDToken, err = s.idToken(user, client.ID, authorizationCode.Scopes, authorizationCode.Nonce.String,
+			authorizationCode.AuthTime)
+		return err
+	})
+	if err != nil {
+		s.discard(ctx, res)
+		if oauthErr, ok := errors.Cause(err).(*oauthError); ok {
+			return nil, oauthErr
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot exchange the authorization code")
+		return nil, errServerError
+	}
+
+	if len(replayed) > 0 {
+		if err = s.authenticator.EvictSession(ctx, replayed); err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot evict the session of the replayed authorization code")
+		}
+		if err = query.RevokeSessionRefreshTokens(ctx, s.db, replayed, time.Now()); err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot revoke the refresh tokens of the replayed authorization code")
+		}
+		return nil, errInvalidCode
+	}
+	return res, nil
+}
+
+// clientCredentials issues an access token for the service account of the client
+func (s *Server) clientCredentials(r *http.Request, client *models.OauthClient) (interface{}, *oauthError) {
+	ctx := r.Context()
+	scopes, oauthErr := grantedScopes(r.PostForm.Get("scope"), client.Scopes)
+	if oauthErr != nil {
+		return nil, oauthErr
+	}
+
+	noAccount := &oauthError{
+		Code:        "unauthorized_client",
+		Description: "the client has no service account",
+		status:      http.StatusBadRequest,
+	}
+	if !client.ServiceAccountID.Valid {
+		return nil, noAccount
+	}
+	account, err := query.FindServiceAccount(ctx, s.db, client.ServiceAccountID.String)
+	if err != nil {
+		if err == query.ErrNoRowsFound {
+			return nil, noAccount
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the service account of the OAuth2 client")
+		return nil, errServerError
+	}
+
+	var res *tokenResponse
+	err = conn.ExecuteTx(ctx, s.db, func(tx *sql.Tx) error {
+		s.discard(ctx, res)
+		// RFC 6749 section 4.4.3, the client asks for a new access token with its credentials instead
+		res, err = s.issue(ctx, tx, client, account, scopes, false)
+		return err
+	})
+	if err != nil {
+		s.discard(ctx, res)
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot issue the access token")
+		return nil, errServerError
+	}
+	return res, nil
+}
+
+// refresh rotates the refresh token: it is revoked, its session is evicted and new tokens are issued for its user
+func (s *Server) refresh(r *http.Request, client *models.OauthClient) (interface{}, *oauthError) {
+	ctx := r.Context()
+	refreshToken := r.PostForm.Get("refresh_token")
+	if len(refreshToken) == 0 {
+		return nil, invalidRequest("refresh_token is missing")
+	}
+
+	var (
+		res      *tokenResponse
+		previous string
+	)
+	err := conn.ExecuteTx(ctx, s.db, func(tx *sql.Tx) error {
+		s.discard(ctx, res)
+		res = nil
+
+		token, err := query.FindRefreshTokenByHash(ctx, tx, hash(refreshToken))
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return errInvalidGrant
+			}
+			return err
+		}
+		now := time.Now()
+		if token.ClientID != client.ID || token.RevokedAt.Valid || !token.ExpiresAt.After(now) {
+			return errInvalidGrant
+		}
+		scopes, oauthErr := grantedScopes(r.PostForm.Get("scope"), token.Scopes)
+		if oauthErr != nil {
+			return oauthErr
+		}
+
+		// the user has to be live, its refresh tokens are revoked when it is deleted
+		user, err := query.FindUser(ctx, tx, &query.UserFilter{ID: token.UserID})
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return errInvalidGrant
+			}
+			return err
+		}
+
+		if err = query.RevokeRefreshToken(ctx, tx, token, now); err != nil {
+			return err
+		}
+		previous = token.SessionID
+		if res, err = s.issue(ctx, tx, client, user, scopes, true); err != nil {
+			return err
+		}
+		res.IDToken, err = s.idToken(user, client.ID, scopes, "", time.Time{})
+		return err
+	})
+	if err != nil {
+		s.discard(ctx, res)
+		if oauthErr, ok := errors.Cause(err).(*oauthError); ok {
+			return nil, oauthErr
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot refresh the access token")
+		return nil, errServerError
+	}
+
+	if err = s.authenticator.EvictSession(ctx, previous); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot evict the session of the refresh token")
+	}
+	return res, nil
+}
+
+// issue creates the session of the access token and, when the grant is refreshable and the client has the
+// refresh_token grant, inserts a refresh token bound to it
+func (s *Server) issue(ctx context.Context, tx *sql.Tx, client *models.OauthClient, user *models.User,
+	scopes []string, refreshable bool) (*tokenResponse, error) {
+	token, session, err := s.authenticator.IssueAccessToken(ctx, user, client.ID, scopes, s.accessTokenLifetime)
+	if err != nil {
+		return nil, err
+	}
+	res := &tokenResponse{
+		AccessToken: token.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.accessTokenLifetime / time.Second),
+		Scope:       strings.Join(scopes, " "),
+		sessionID:   session.ID,
+	}
+	if !refreshable || !contains(client.GrantTypes, GrantRefreshToken) {
+		return res, nil
+	}
+
+	if res.RefreshToken, err = random(RefreshTokenPrefix, secretBytes); err != nil {
+		return res, errors.WithMessage(err, "cannot generate the refresh token")
+	}
+	err = query.CreateRefreshToken(ctx, tx, &models.OauthRefreshToken{
+		TokenHash: hash(res.RefreshToken),
+		ClientID:  client.ID,
+		UserID:    user.ID,
+		SessionID: session.ID,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(s.refreshTokenLifetime),
+		CreatedAt: time.Now(),
+	})
+	return res, err
+}
+
+// discard evicts the session of tokens that were not committed
+func (s *Server) discard(ctx context.Context, res *tokenResponse) {
+	if res == nil {
+		return
+	}
+	if err := s.authenticator.EvictSession(ctx, res.sessionID); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot evict the session of the discarded access token")
+	}
+}
+
+// introspect tells the authenticated clients whether an access token or a refresh token is active
+func (s *Server) introspect(r *http.Request) (interface{}, *oauthError) {
+	ctx := r.Context()
+	client, oauthErr := s.authenticateClient(r)
+	if oauthErr != nil {
+		return nil, oauthErr
+	}
+	token := r.PostForm.Get("token")
+	if len(token) == 0 {
+		return nil, invalidRequest("token is missing")
+	}
+
+	// a client only introspects its own tokens, the others are inactive to it
+	if strings.HasPrefix(token, RefreshTokenPrefix) {
+		refreshToken, err := query.FindRefreshTokenByHash(ctx, s.db, hash(token))
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return &introspectionResponse{}, nil
+			}
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the refresh token")
+			return nil, errServerError
+		}
+		if refreshToken.ClientID != client.ID || refreshToken.RevokedAt.Valid || !refreshToken.ExpiresAt.After(time.Now()) {
+			return &introspectionResponse{}, nil
+		}
+		user, err := query.FindUser(ctx, s.db, &query.UserFilter{ID: refreshToken.UserID})
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return &introspectionResponse{}, nil
+			}
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot retrieve the user of the refresh token")
+			return nil, errServerError
+		}
+		return &introspectionResponse{
+			Active:    true,
+			Scope:     strings.Join(refreshToken.Scopes, " "),
+			ClientID:  refreshToken.ClientID,
+			Username:  user.Username,
+			Subject:   user.ID,
+			ExpiresAt: refreshToken.ExpiresAt.Unix(),
+			IssuedAt:  refreshToken.CreatedAt.Unix(),
+		}, nil
+	}
+
+	claims, session, err := s.authenticator.IntrospectToken(ctx, token)
+	if err != nil {
+		if err == auth.ErrInvalidCredentialsError {
+			return &introspectionResponse{}, nil
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot introspect the access token")
+		return nil, errServerError
+	}
+	if session.ClientID != client.ID {
+		return &introspectionResponse{}, nil
+	}
+	return &introspectionResponse{
+		Active:    true,
+		Scope:     strings.Join(session.Scopes, " "),
+		ClientID:  session.ClientID,
+		Username:  claims.Username,
+		Subject:   claims.ID,
+		ExpiresAt: claims.ExpiresAt,
+		IssuedAt:  claims.IssuedAt,
+		TokenType: "Bearer",
+	}, nil
+}
+
+// revoke revokes an access token or a refresh token of the authenticated client with the other tokens of its grant.
+// The unknown, expired and already revoked tokens are not an error.
+func (s *Server) revoke(r *http.Request) (interface{}, *oauthError) {
+	ctx := r.Context()
+	client, oauthErr := s.authenticateClient(r)
+	if oauthErr != nil {
+		return nil, oauthErr
+	}
+	token := r.PostForm.Get("token")
+	if len(token) == 0 {
+		return nil, invalidRequest("token is missing")
+	}
+	otherClient := &oauthError{
+		Code:        "unauthorized_client",
+		Description: "the token was not issued to the client",
+		status:      http.StatusBadRequest,
+	}
+
+	var sessionID string
+	if strings.HasPrefix(token, RefreshTokenPrefix) {
+		err := conn.ExecuteTx(ctx, s.db, func(tx *sql.Tx) error {
+			refreshToken, err := query.FindRefreshTokenByHash(ctx, tx, hash(token))
+			if err != nil {
+				return err
+			}
+			if refreshToken.ClientID != client.ID {
+				return otherClient
+			}
+			sessionID = refreshToken.SessionID
+			return query.RevokeRefreshToken(ctx, tx, refreshToken, time.Now())
+		})
+		switch errors.Cause(err) {
+		case nil:
+		case query.ErrNoRowsFound:
+			return struct{}{}, nil
+		case otherClient:
+			return nil, otherClient
+		default:
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot revoke the refresh token")
+			return nil, errServerError
+		}
+	} else {
+		_, session, err := s.authenticator.IntrospectToken(ctx, token)
+		if err != nil {
+			if err == auth.ErrInvalidCredentialsError {
+				return struct{}{}, nil
+			}
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot introspect the access token")
+			return nil, errServerError
+		}
+		if session.ClientID != client.ID {
+			return nil, otherClient
+		}
+		sessionID = session.ID
+		if err = query.RevokeSessionRefreshTokens(ctx, s.db, sessionID, time.Now()); err != nil {
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot revoke the refresh tokens of the access token")
+			return nil, errServerError
+		}
+	}
+
+	if err := s.authenticator.EvictSession(ctx, sessionID); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("cannot evict the session of the revoked token")
+		return nil, errServerError
+	}
+	return struct{}{}, nil
+}
@@ -0,0 +1,432 @@
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// Version of the OpenAPI specification the document follows
+	Version = "3.0.3"
+
+	errorSchema       = "Error"
+	streamErrorSchema = "StreamError"
+	timestampType     = ".google.protobuf.Timestamp"
+)
+
+// errorCodes are the gRPC codes the service responds with, the unauthenticated code is only documented on the
+// operations requiring a session
+var errorCodes = []codes.Code{
+	codes.InvalidArgument,
+	codes.FailedPrecondition,
+	codes.OutOfRange,
+	codes.PermissionDenied,
+	codes.NotFound,
+	codes.AlreadyExists,
+	codes.ResourceExhausted,
+	codes.Internal,
+	codes.Unavailable,
+}
+
+var pathParam = regexp.MustCompile(`{([^}=]+)}`)
+
+type (
+	// Document is the subset of an OpenAPI document the REST gateway needs
+	Document struct {
+		OpenAPI    string              `json:"openapi"`
+		Info       Info                `json:"info"`
+		Paths      map[string]PathItem `json:"paths"`
+		Components Components          `json:"components"`
+		Tags       []Tag               `json:"tags,omitempty"`
+	}
+
+	Info struct {
+		Title       string `json:"title"`
+		Description string `json:"description,omitempty"`
+		Version     string `json:"version"`
+	}
+
+	Tag struct {
+		Name string `json:"name"`
+	}
+
+	// PathItem maps the lower case HTTP methods to their operation
+	PathItem map[string]*Operation
+
+	Operation struct {
+		OperationID string                `json:"operationId"`
+		Tags        []string              `json:"tags"`
+		Description string                `json:"description,omitempty"`
+		Parameters  []Parameter           `json:"parameters,omitempty"`
+		RequestBody *RequestBody          `json:"requestBody,omitempty"`
+		Responses   map[string]Response   `json:"responses"`
+		Security    []map[string][]string `json:"security,omitempty"`
+	}
+
+	Parameter struct {
+		Name     string  `json:"name"`
+		In       string  `json:"in"`
+		Required bool    `json:"required,omitempty"`
+		Schema   *Schema `json:"schema"`
+	}
+
+	RequestBody struct {
+		Content map[string]MediaType `json:"content"`
+	}
+
+	Response struct {
+		Description string               `json:"description"`
+		Content     map[string]MediaType `json:"content,omitempty"`
+	}
+
+	MediaType struct {
+		Schema *Schema `json:"schema"`
+	}
+
+	Schema struct {
+		Ref                  string             `json:"$ref,omitempty"`
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Description          string             `json:"description,omitempty"`
+		Enum                 []string           `json:"enum,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	}
+
+	Components struct {
+		Schemas         map[string]*Schema        `json:"schemas"`
+		SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+	}
+
+	SecurityScheme struct {
+		Type         string `json:"type"`
+		Scheme       string `json:"scheme"`
+		BearerFormat string `json:"bearerFormat,omitempty"`
+	}
+)
+
+// Generate builds the OpenAPI document of the REST routes declared with the google.api.http annotations of the proto
+// file, like "message/message.proto". The public methods, by full method name, are documented without security.
+func Generate(protoFile string, public map[string]bool) (*Document, error) {
+	file, err := fileDescriptor(protoFile)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title: "user.app",
+			Description: "The REST/JSON gateway of the UserApp gRPC service. The errors are the gRPC statuses, " +
+				"their HTTP status is derived from the gRPC code.",
+			Version: "v1",
+		},
+		Paths: make(map[string]PathItem),
+		Components: Components{
+			Schemas: map[string]*Schema{
+				errorSchema:       errorBody(),
+				streamErrorSchema: streamErrorBody(),
+			},
+			SecuritySchemes: map[string]SecurityScheme{
+				"bearer": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	messages := make(map[string]*descpb.DescriptorProto)
+	for _, message := range file.MessageType {
+		messages["."+file.GetPackage()+"."+message.GetName()] = message
+		doc.Components.Schemas[message.GetName()] = messageSchema(message)
+	}
+	for _, enum := range file.EnumType {
+		schema := &Schema{Type: "string"}
+		for _, value := range enum.Value {
+			schema.Enum = append(schema.Enum, value.GetName())
+		}
+		doc.Components.Schemas[enum.GetName()] = schema
+	}
+
+	for _, service := range file.Service {
+		doc.Tags = append(doc.Tags, Tag{Name: service.GetName()})
+		for _, method := range service.Method {
+			fullMethod := fmt.Sprintf("/%s.%s/%s", file.GetPackage(), service.GetName(), method.GetName())
+			rule, err := httpRule(method)
+			if err != nil {
+				return nil, errors.WithMessagef(err, "invalid http rule of %s", fullMethod)
+			}
+			if rule == nil {
+				continue
+			}
+			input, ok := messages[method.GetInputType()]
+			if !ok {
+				return nil, errors.Errorf("unknown input type %s of %s", method.GetInputType(), fullMethod)
+			}
+
+			httpMethod, path := pattern(rule)
+			operation := &Operation{
+				OperationID: method.GetName(),
+				Tags:        []string{service.GetName()},
+				Parameters:  parameters(input, path, rule.GetBody()),
+				Responses:   responses(method, !public[fullMethod]),
+			}
+			if !public[fullMethod] {
+				operation.Security = []map[string][]string{{"bearer": {}}}
+			}
+			if len(rule.GetBody()) > 0 {
+				operation.RequestBody = &RequestBody{Content: jsonContent(ref(method.GetInputType()))}
+			}
+			if method.GetServerStreaming() {
+				operation.Description = "The response is a stream of JSON objects separated by new lines, each one " +
+					"holding either a result or the error that ended the stream."
+			}
+
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = make(PathItem)
+				doc.Paths[path] = item
+			}
+			item[httpMethod] = operation
+		}
+	}
+	return doc, nil
+}
+
+// Marshal encodes the document the way it is checked in
+func Marshal(doc *Document) ([]byte, error) {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode the document")
+	}
+	return append(b, '\n'), nil
+}
+
+// Handler serves the encoded document
+func Handler(spec []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(spec)
+	})
+}
+
+// fileDescriptor returns the descriptor compiled in the generated code of the proto file
+func fileDescriptor(protoFile string) (*descpb.FileDescriptorProto, error) {
+	compressed := proto.FileDescriptor(protoFile)
+	if compressed == nil {
+		return nil, errors.Errorf("proto file %s is not registered", protoFile)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decompress the file descriptor")
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decompress the file descriptor")
+	}
+	file := &descpb.FileDescriptorProto{}
+	if err = proto.Unmarshal(b, file); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the file descriptor")
+	}
+	return file, nil
+}
+
+// httpRule returns the google.api.http annotation of the method, nil when it has none
+func httpRule(method *descpb.MethodDescriptorProto) (*annotations.HttpRule, error) {
+	if method.Options == nil || !proto.HasExtension(method.Options, annotations.E_Http) {
+		return nil, nil
+	}
+	ext, err := proto.GetExtension(method.Options, annotations.E_Http)
+	if err != nil {
+		return nil, err
+	}
+	rule, ok := ext.(*annotations.HttpRule)
+	if !ok {
+		return nil, errors.New("unexpected extension type")
+	}
+	return rule, nil
+}
+
+// pattern returns the lower case HTTP method and the path of the rule
+func pattern(rule *annotations.HttpRule) (string, string) {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "get", p.Get
+	case *annotations.HttpRule_Put:
+		return "put", p.Put
+	case *annotations.HttpRule_Post:
+		return "post", p.Post
+	case *annotations.HttpRule_Delete:
+		return "delete", p.Delete
+	case *annotations.HttpRule_Patch:
+		return "patch", p.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToLower(p.Custom.GetKind()), p.Custom.GetPath()
+	}
+	return "", ""
+}
+
+// parameters returns the path parameters and, when the request has no body, the query parameters. The nested
+// messages cannot be sent in the query.
+func parameters(input *descpb.DescriptorProto, path, body string) []Parameter {
+	var params []Parameter
+	inPath := make(map[string]bool)
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		inPath[match[1]] = true
+		params = append(params, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	if len(body) > 0 {
+		return params
+	}
+	for _, field := range input.Field {
+		if inPath[field.GetName()] || field.GetType() == descpb.FieldDescriptorProto_TYPE_MESSAGE {
+			continue
+		}
+		params = append(params, Parameter{Name: field.GetName(), In: "query", Schema: fieldSchema(field)})
+	}
+	return params
+}
+
+// responses documents the result and the errors of the method, by the HTTP status of their gRPC code
+func responses(method *descpb.MethodDescriptorProto, authenticated bool) map[string]Response {
+	result := Response{Description: "A successful response.", Content: jsonContent(ref(method.GetOutputType()))}
+	if method.GetServerStreaming() {
+		result = Response{
+			Description: "A stream of results.",
+			Content: jsonContent(&Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"result": ref(method.GetOutputType()),
+					"error":  {Ref: "#/components/schemas/" + streamErrorSchema},
+				},
+			}),
+		}
+	}
+
+	documented := errorCodes
+	if authenticated {
+		documented = append([]codes.Code{codes.Unauthenticated}, errorCodes...)
+	}
+	byStatus := make(map[int][]string)
+	for _, code := range documented {
+		httpStatus := runtime.HTTPStatusFromCode(code)
+		byStatus[httpStatus] = append(byStatus[httpStatus], code.String())
+	}
+
+	res := map[string]Response{"200": result}
+	for httpStatus, grpcCodes := range byStatus {
+		sort.Strings(grpcCodes)
+		res[fmt.Sprint(httpStatus)] = Response{
+			Description: "gRPC " + strings.Join(grpcCodes, ", "),
+			Content:     jsonContent(&Schema{Ref: "#/components/schemas/" + errorSchema}),
+		}
+	}
+	return res
+}
+
+func messageSchema(message *descpb.DescriptorProto) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range message.Field {
+		schema.Properties[field.GetName()] = fieldSchema(field)
+	}
+	return schema
+}
+
+// fieldSchema follows the JSON mapping of proto3, the 64 bits integers are strings
+func fieldSchema(field *descpb.FieldDescriptorProto) *Schema {
+	var schema *Schema
+	switch field.GetType() {
+	case descpb.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.GetTypeName() == timestampType {
+			schema = &Schema{Type: "string", Format: "date-time"}
+		} else {
+			schema = ref(field.GetTypeName())
+		}
+	case descpb.FieldDescriptorProto_TYPE_ENUM:
+		schema = ref(field.GetTypeName())
+	case descpb.FieldDescriptorProto_TYPE_STRING:
+		schema = &Schema{Type: "string"}
+	case descpb.FieldDescriptorProto_TYPE_BYTES:
+		schema = &Schema{Type: "string", Format: "byte"}
+	case descpb.FieldDescriptorProto_TYPE_BOOL:
+		schema = &Schema{Type: "boolean"}
+	case descpb.FieldDescriptorProto_TYPE_DOUBLE:
+		schema = &Schema{Type: "number", Format: "double"}
+	case descpb.FieldDescriptorProto_TYPE_FLOAT:
+		schema = &Schema{Type: "number", Format: "float"}
+	case descpb.FieldDescriptorProto_TYPE_INT64, descpb.FieldDescriptorProto_TYPE_SINT64,
+		descpb.FieldDescriptorProto_TYPE_SFIXED64:
+		schema = &Schema{Type: "string", Format: "int64"}
+	case descpb.FieldDescriptorProto_TYPE_UINT64, descpb.FieldDescriptorProto_TYPE_FIXED64:
+		schema = &Schema{Type: "string", Format: "uint64"}
+	case descpb.FieldDescriptorProto_TYPE_UINT32, descpb.FieldDescriptorProto_TYPE_FIXED32:
+		schema = &Schema{Type: "integer", Format: "int64"}
+	default:
+		schema = &Schema{Type: "integer", Format: "int32"}
+	}
+	if field.GetLabel() == descpb.FieldDescriptorProto_LABEL_REPEATED {
+		return &Schema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+// ref references the schema of a fully qualified proto type, like ".message.Empty"
+func ref(typeName string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + typeName[strings.LastIndex(typeName, ".")+1:]}
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// errorBody is the body of the gateway's errors, see runtime.DefaultHTTPError
+func errorBody() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":   {Type: "string"},
+			"code":    {Type: "integer", Format: "int32", Description: "the gRPC code"},
+			"message": {Type: "string"},
+			"details": {Type: "array", Items: anySchema()},
+		},
+	}
+}
+
+// streamErrorBody is the error ending a stream, see runtime.DefaultHTTPStreamErrorHandler
+func streamErrorBody() *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"grpc_code":   {Type: "integer", Format: "int32"},
+			"http_code":   {Type: "integer", Format: "int32"},
+			"message":     {Type: "string"},
+			"http_status": {Type: "string"},
+			"details":     {Type: "array", Items: anySchema()},
+		},
+	}
+}
+
+func anySchema() *Schema {
+	return &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{"@type": {Type: "string"}},
+		AdditionalProperties: &Schema{},
+	}
+}
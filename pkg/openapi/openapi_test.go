@@ -0,0 +1,206 @@
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"user.app/pkg/api"
+)
+
+const (
+	// specFile is the checked-in document, regenerate it with `go run main.go openapi > message/openapi.json`
+	specFile = "../../message/openapi.json"
+	// protoFile is the source of message.pb.go
+	protoFile = "../../message/message.proto"
+)
+
+// TestCheckedInSpecMatchesTheCompiledDescriptor checks the document against the descriptor compiled in message.pb.go,
+// TestCompiledDescriptorMatchesTheProto checks that one against message.proto
+func TestCheckedInSpecMatchesTheCompiledDescriptor(t *testing.T) {
+	doc, err := Generate("message/message.proto", api.PublicMethods)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	generated, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	checkedIn, err := ioutil.ReadFile(specFile)
+	if err != nil {
+		t.Fatalf("cannot read the checked-in document: %v", err)
+	}
+	if !bytes.Equal(generated, checkedIn) {
+		t.Fatalf("%s is out of date with message.pb.go, regenerate it with `go run main.go openapi > message/openapi.json`",
+			specFile)
+	}
+}
+
+func TestEveryRouteIsDocumented(t *testing.T) {
+	doc, err := Generate("message/message.proto", api.PublicMethods)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for path, method := range map[string]string{
+		"/v1/users":                   "post",
+		"/v1/sessions":                "post",
+		"/v1/users/me":                "patch",
+		"/v1/users/{user_id}/restore": "post",
+	} {
+		if _, ok := doc.Paths[path][method]; !ok {
+			t.Errorf("%s %s is not documented", method, path)
+		}
+	}
+	if security := doc.Paths["/v1/sessions"]["post"].Security; len(security) > 0 {
+		t.Error("SignIn is documented as requiring a session")
+	}
+	if _, ok := doc.Paths["/v1/users/me"]["delete"].Responses["401"]; !ok {
+		t.Error("DeleteUser does not document the unauthenticated error")
+	}
+}
+
+var (
+	declaration = regexp.MustCompile(`^(message|enum|service) (\w+) {`)
+	field       = regexp.MustCompile(`^\s+(repeated )?([\w.]+) (\w+) = (\d+);`)
+	enumValue   = regexp.MustCompile(`^\s+(\w+) = (\d+);`)
+	rpc         = regexp.MustCompile(`^\s+rpc (\w+) \((stream )?(\w+)\) returns \((stream )?(\w+)\)`)
+	binding     = regexp.MustCompile(`^\s+(get|put|post|delete|patch): "([^"]+)"`)
+)
+
+// protoSignatures returns one line per field, enum value and method declared in the proto file. It only understands
+// the subset of the language message.proto uses.
+func protoSignatures(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var signatures []string
+	var kind, name, method string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := declaration.FindStringSubmatch(line); m != nil {
+			kind, name = m[1], m[2]
+			continue
+		}
+		switch kind {
+		case "message":
+			if m := field.FindStringSubmatch(line); m != nil {
+				signatures = append(signatures, fmt.Sprintf("%s.%s = %s %s%s", name, m[3], m[4], m[1], shortType(m[2])))
+			}
+		case "enum":
+			if m := enumValue.FindStringSubmatch(line); m != nil {
+				signatures = append(signatures, fmt.Sprintf("%s.%s = %s", name, m[1], m[2]))
+			}
+		case "service":
+			if m := rpc.FindStringSubmatch(line); m != nil {
+				method = m[1]
+				signatures = append(signatures, fmt.Sprintf("%s.%s(%s%s) %s%s", name, m[1], m[2], m[3], m[4], m[5]))
+			} else if m := binding.FindStringSubmatch(line); m != nil {
+				signatures = append(signatures, fmt.Sprintf("%s.%s %s %s", name, method, m[1], m[2]))
+			}
+		}
+	}
+	sort.Strings(signatures)
+	return signatures, scanner.Err()
+}
+
+// descriptorSignatures returns the signatures of protoSignatures from the compiled descriptor
+func descriptorSignatures(file *descpb.FileDescriptorProto) ([]string, error) {
+	var signatures []string
+	for _, message := range file.MessageType {
+		for _, f := range message.Field {
+			typeName := strings.ToLower(strings.TrimPrefix(f.GetType().String(), "TYPE_"))
+			if len(f.GetTypeName()) > 0 {
+				typeName = shortType(f.GetTypeName())
+			}
+			repeated := ""
+			if f.GetLabel() == descpb.FieldDescriptorProto_LABEL_REPEATED {
+				repeated = "repeated "
+			}
+			signatures = append(signatures, fmt.Sprintf("%s.%s = %d %s%s", message.GetName(), f.GetName(), f.GetNumber(),
+				repeated, typeName))
+		}
+	}
+	for _, enum := range file.EnumType {
+		for _, value := range enum.Value {
+			signatures = append(signatures, fmt.Sprintf("%s.%s = %d", enum.GetName(), value.GetName(), value.GetNumber()))
+		}
+	}
+	stream := func(streaming bool) string {
+		if streaming {
+			return "stream "
+		}
+		return ""
+	}
+	for _, service := range file.Service {
+		for _, m := range service.Method {
+			signatures = append(signatures, fmt.Sprintf("%s.%s(%s%s) %s%s", service.GetName(), m.GetName(),
+				stream(m.GetClientStreaming()), shortType(m.GetInputType()),
+				stream(m.GetServerStreaming()), shortType(m.GetOutputType())))
+			rule, err := httpRule(m)
+			if err != nil {
+				return nil, err
+			}
+			if rule != nil {
+				verb, path := pattern(rule)
+				signatures = append(signatures, fmt.Sprintf("%s.%s %s %s", service.GetName(), m.GetName(), verb, path))
+			}
+		}
+	}
+	sort.Strings(signatures)
+	return signatures, nil
+}
+
+// shortType drops the package of a type name
+func shortType(typeName string) string {
+	return typeName[strings.LastIndex(typeName, ".")+1:]
+}
+
+func TestCompiledDescriptorMatchesTheProto(t *testing.T) {
+	file, err := fileDescriptor("message/message.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled, err := descriptorSignatures(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	declared, err := protoSignatures(protoFile)
+	if err != nil {
+		t.Fatalf("cannot read %s: %v", protoFile, err)
+	}
+	if len(declared) == 0 {
+		t.Fatalf("nothing declared in %s", protoFile)
+	}
+	if !reflect.DeepEqual(compiled, declared) {
+		t.Fatalf("message.pb.go is out of date with message.proto, regenerate it with protoc:\n"+
+			"only compiled %v\nonly declared %v", missing(compiled, declared), missing(declared, compiled))
+	}
+}
+
+// missing returns the signatures of a missing from b
+func missing(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, signature := range b {
+		in[signature] = true
+	}
+	var diff []string
+	for _, signature := range a {
+		if !in[signature] {
+			diff = append(diff, signature)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,173 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gofrs/uuid"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"time"
+	"user.app/models"
+	"user.app/pkg/events"
+	"user.app/pkg/query"
+)
+
+const (
+	// DefaultWatchPollIntervalInMilliseconds is used when watch.poll_interval is not configured
+	DefaultWatchPollIntervalInMilliseconds = 1000
+
+	// watchBatchSize is the number of events read at once by Watch
+	watchBatchSize = 500
+
+	// StatusPending is the status of a delivery waiting for its next attempt
+	StatusPending = "pending"
+	// StatusDelivered is the status of a delivery acknowledged by its endpoint
+	StatusDelivered = "delivered"
+	// StatusDead is the status of a delivery that failed all its attempts, it is only retried when replayed
+	StatusDead = "dead"
+)
+
+var ErrCursorExpired = errors.New("the events after the cursor have been pruned")
+
+// Published are the events written to the outbox, the sign ins stay in-process
+var Published = []events.Type{
+	events.UserCreated,
+	events.UserUpdated,
+	events.UserDeleted,
+	events.UserRestored,
+	events.UserErased,
+	events.PasswordChanged,
+}
+
+// Message is the payload of an outbox event, it is the body of the webhooks. Like the events it only carries the
+// names of the changed columns, the receivers have to fetch the user to get the new values.
+type Message struct {
+	ID             string    `json:"id"`
+	Type           string    `json:"type"`
+	UserID         string    `json:"user_id"`
+	ActorID        string    `json:"actor_id,omitempty"`
+	ChangedColumns []string  `json:"changed_columns,omitempty"`
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// Subscribe writes the published events to the outbox, in the transaction of the mutation, and schedules one delivery
+// per endpoint
+func Subscribe(bus *events.Bus, endpoints []string) {
+	bus.Subscribe(func(ctx context.Context, exec boil.ContextExecutor, event events.Event) error {
+		return Write(ctx, exec, event, endpoints)
+	}, Published...)
+}
+
+// Write adds the event to the outbox and schedules its delivery to the endpoints. The seq follows the last event, the
+// writers serialize on it so that a reader resuming after a seq never misses an event committed later.
+func Write(ctx context.Context, exec boil.ContextExecutor, event events.Event, endpoints []string) error {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return errors.Wrap(err, "cannot create the UUID")
+	}
+
+	payload, err := json.Marshal(Message{
+		ID:             id.String(),
+		Type:           string(event.Type),
+		UserID:         event.UserID,
+		ActorID:        event.ActorID,
+		ChangedColumns: event.ChangedColumns,
+		OccurredAt:     event.OccurredAt.UTC(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal the outbox message")
+	}
+
+	last, err := query.LastOutboxEvent(ctx, exec)
+	if err != nil {
+		return errors.WithMessage(err, "cannot retrieve the last outbox event")
+	}
+
+	now := time.Now()
+	row := &models.Outbox{
+		ID:        id.String(),
+		Seq:       1,
+		EventType: string(event.Type),
+		UserID:    event.UserID,
+		Payload:   payload,
+		CreatedAt: now,
+	}
+	if last != nil {
+		row.Seq = last.Seq + 1
+	}
+	if err = query.CreateOutboxEvent(ctx, exec, row); err != nil {
+		return errors.WithMessage(err, "cannot write the outbox event")
+	}
+
+	for _, endpoint := range endpoints {
+		err = query.CreateWebhookDelivery(ctx, exec, &models.WebhookDelivery{
+			OutboxID:      row.ID,
+			Endpoint:      endpoint,
+			Status:        StatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return errors.WithMessagef(err, "cannot schedule the delivery to %s", endpoint)
+		}
+	}
+	return nil
+}
+
+// WatchPollInterval is how often Watch looks for new events
+func WatchPollInterval() time.Duration {
+	milliseconds := viper.GetInt("watch.poll_interval")
+	if milliseconds <= 0 {
+		milliseconds = DefaultWatchPollIntervalInMilliseconds
+	}
+	return time.Duration(milliseconds) * time.Millisecond
+}
+
+// Watch sends the events with a seq greater than the cursor, then polls for new ones until the context is done or
+// send fails. A negative cursor starts after the last event. Every watcher polls the outbox on its own, so a slow
+// watcher only delays itself and never the writers. It fails with ErrCursorExpired when the events following the
+// cursor have already been pruned.
+func Watch(ctx context.Context, exec boil.ContextExecutor, cursor int64, types []string, send func(*models.Outbox) error) error {
+	var err error
+	if cursor < 0 {
+		if cursor, err = query.LastOutboxSeq(ctx, exec); err != nil {
+			return errors.WithMessage(err, "cannot retrieve the last outbox event")
+		}
+	} else if cursor > 0 {
+		first, err := query.FirstOutboxSeq(ctx, exec)
+		if err != nil {
+			return errors.WithMessage(err, "cannot retrieve the first outbox event")
+		}
+		if first > cursor+1 {
+			return ErrCursorExpired
+		}
+	}
+
+	ticker := time.NewTicker(WatchPollInterval())
+	defer ticker.Stop()
+
+	for {
+		for {
+			events, err := query.ListOutboxEvents(ctx, exec, cursor, types, watchBatchSize)
+			if err != nil {
+				return errors.WithMessage(err, "cannot list the outbox events")
+			}
+			for _, event := range events {
+				if err = send(event); err != nil {
+					return err
+				}
+				cursor = event.Seq
+			}
+			if len(events) < watchBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
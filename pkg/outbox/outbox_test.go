@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/viper"
+	"testing"
+	"time"
+	"user.app/models"
+	"user.app/pkg/conn"
+	"user.app/pkg/events"
+	"user.app/pkg/query"
+)
+
+// TestPruneKeepsTheEventsAfterAnUndeliveredOne prunes the outbox against CockroachDB, in a transaction that is rolled
+// back. It is skipped when the database of configs/config.toml is not reachable.
+func TestPruneKeepsTheEventsAfterAnUndeliveredOne(t *testing.T) {
+	viper.SetConfigFile("../../configs/config.toml")
+	if err := viper.ReadInConfig(); err != nil {
+		t.Fatal(err)
+	}
+	instance, err := conn.InitDBConnection()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer instance.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err = instance.PingContext(ctx); err != nil {
+		t.Skipf("CockroachDB is not reachable: %v", err)
+	}
+
+	tx, err := instance.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	// the second event is pending, the others are delivered and all of them are older than the retention
+	statuses := []string{StatusDelivered, StatusPending, StatusDelivered, StatusDelivered, StatusDelivered}
+	written := make([]*models.Outbox, 0, len(statuses))
+	for _, status := range statuses {
+		userID, _ := uuid.NewV4()
+		if err = Write(ctx, tx, events.Event{Type: events.UserUpdated, UserID: userID.String()}, []string{"billing"}); err != nil {
+			t.Fatal(err)
+		}
+		event, err := query.LastOutboxEvent(ctx, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tx.ExecContext(ctx, `UPDATE outbox SET created_at = now() - INTERVAL '30 days' WHERE id = $1`,
+			event.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tx.ExecContext(ctx, `UPDATE webhook_deliveries SET status = $1 WHERE outbox_id = $2`, status,
+			event.ID); err != nil {
+			t.Fatal(err)
+		}
+		written = append(written, event)
+	}
+
+	if _, err = query.PruneOutbox(ctx, tx, time.Now().Add(-time.Hour), StatusDelivered); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := query.ListOutboxEvents(ctx, tx, written[0].Seq, nil, len(written))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != len(written)-1 {
+		t.Fatalf("%d events left after the first one, want the %d following it", len(remaining), len(written)-1)
+	}
+
+	// a watcher resuming after the first event gets every event following it
+	var watched []int64
+	watchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	err = Watch(watchCtx, tx, written[0].Seq, nil, func(event *models.Outbox) error {
+		watched = append(watched, event.Seq)
+		if event.Seq == written[len(written)-1].Seq {
+			stop()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("the watch ended with %v", err)
+	}
+	for i, seq := range watched {
+		if seq != written[i+1].Seq {
+			t.Fatalf("watched the seqs %v after %d, want the ones of %v", watched, written[0].Seq, written[1:])
+		}
+	}
+}
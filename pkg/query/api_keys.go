@@ -0,0 +1,124 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// CreateAPIKey inserts the API key, its hash has to be computed by the caller
+func CreateAPIKey(ctx context.Context, exec boil.ContextExecutor, key *models.APIKey) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateAPIKey")
+	defer span.End()
+
+	if err := key.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+
+	logging.Ctx(ctx).Info().Msg("inserted successfully")
+	return nil
+}
+
+// FindAPIKeyByPrefix returns the API key having the prefix, revoked and expired included, ErrNoRowsFound when there
+// is none
+func FindAPIKeyByPrefix(ctx context.Context, exec boil.ContextExecutor, prefix string) (*models.APIKey, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindAPIKeyByPrefix")
+	defer span.End()
+
+	key, err := models.APIKeys(
+		models.APIKeyWhere.Prefix.EQ(prefix),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, ErrNoRowsFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return key, nil
+}
+
+// ListAllUserAPIKeys returns every API key of the user, the revoked ones included, the oldest first
+func ListAllUserAPIKeys(ctx context.Context, exec boil.ContextExecutor, userID string) (models.APIKeySlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListAllUserAPIKeys")
+	defer span.End()
+
+	keys, err := models.APIKeys(
+		models.APIKeyWhere.UserID.EQ(userID),
+		qm.OrderBy(models.APIKeyColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ListUserAPIKeys returns the API keys of the user that have not been revoked, the most recent first
+func ListUserAPIKeys(ctx context.Context, exec boil.ContextExecutor, userID string) (models.APIKeySlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListUserAPIKeys")
+	defer span.End()
+
+	keys, err := models.APIKeys(
+		models.APIKeyWhere.UserID.EQ(userID),
+		models.APIKeyWhere.RevokedAt.IsNull(),
+		qm.OrderBy(models.APIKeyColumns.CreatedAt+" DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey revokes the API key of the user, ErrNoRowsFound when the user has no such key or it is already revoked
+func RevokeAPIKey(ctx context.Context, exec boil.ContextExecutor, userID, keyID string, revokedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "RevokeAPIKey")
+	defer span.End()
+
+	revoked, err := models.APIKeys(
+		models.APIKeyWhere.ID.EQ(keyID),
+		models.APIKeyWhere.UserID.EQ(userID),
+		models.APIKeyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.APIKeyColumns.RevokedAt: null.TimeFrom(revokedAt)})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	if revoked == 0 {
+		return ErrNoRowsFound
+	}
+	return nil
+}
+
+// TouchAPIKey records the last use of the API key
+func TouchAPIKey(ctx context.Context, exec boil.ContextExecutor, key *models.APIKey, usedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "TouchAPIKey")
+	defer span.End()
+
+	key.LastUsedAt = null.TimeFrom(usedAt)
+	if _, err := key.Update(ctx, exec, boil.Whitelist(models.APIKeyColumns.LastUsedAt)); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("update failed")
+		return err
+	}
+	return nil
+}
+
+// DeleteUserAPIKeys deletes every API key of the user, revoked included
+func DeleteUserAPIKeys(ctx context.Context, exec boil.ContextExecutor, userID string) error {
+	ctx, exec, span := instrument(ctx, exec, "DeleteUserAPIKeys")
+	defer span.End()
+
+	if _, err := models.APIKeys(models.APIKeyWhere.UserID.EQ(userID)).DeleteAll(ctx, exec); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("deletion failed")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// AuditEventFilter narrows ListAuditEvents down to the events of an actor or of a target user
+type AuditEventFilter struct {
+	ActorID      string
+	TargetUserID string
+}
+
+func (f *AuditEventFilter) apply(mod []qm.QueryMod) []qm.QueryMod {
+	if f == nil {
+		return mod
+	}
+	if len(f.ActorID) > 0 {
+		mod = append(mod, models.AuditEventWhere.ActorID.EQ(null.StringFrom(f.ActorID)))
+	}
+	if len(f.TargetUserID) > 0 {
+		mod = append(mod, models.AuditEventWhere.TargetUserID.EQ(null.StringFrom(f.TargetUserID)))
+	}
+	return mod
+}
+
+// LastAuditEvent locks and returns the head of the audit chain, nil when the chain is empty. Concurrent writers
+// serialize on the lock, the conflicts are retried by conn.ExecuteTx.
+func LastAuditEvent(ctx context.Context, exec boil.ContextExecutor) (*models.AuditEvent, error) {
+	ctx, exec, span := instrument(ctx, exec, "LastAuditEvent")
+	defer span.End()
+
+	event, err := models.AuditEvents(
+		qm.OrderBy(models.AuditEventColumns.Seq+" DESC"),
+		qm.Limit(1),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return event, nil
+}
+
+// CreateAuditEvent appends the event to the audit log
+func CreateAuditEvent(ctx context.Context, exec boil.ContextExecutor, event *models.AuditEvent) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateAuditEvent")
+	defer span.End()
+
+	if err := event.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+	return nil
+}
+
+// ListAuditEvents returns at most limit events matching the filter with a seq greater than afterSeq, in chain order
+func ListAuditEvents(ctx context.Context, exec boil.ContextExecutor, filter *AuditEventFilter, afterSeq int64, limit int) (models.AuditEventSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListAuditEvents")
+	defer span.End()
+
+	var queryMod = []qm.QueryMod{
+		models.AuditEventWhere.Seq.GT(afterSeq),
+		qm.OrderBy(models.AuditEventColumns.Seq),
+		qm.Limit(limit),
+	}
+	queryMod = filter.apply(queryMod)
+
+	events, err := models.AuditEvents(queryMod...).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,41 @@
+package query
+
+import (
+	"context"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// CreateErasureReceipt records the erasure of a user
+func CreateErasureReceipt(ctx context.Context, exec boil.ContextExecutor, receipt *models.ErasureReceipt) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateErasureReceipt")
+	defer span.End()
+
+	if err := receipt.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+
+	logging.Ctx(ctx).Info().Msg("inserted successfully")
+	return nil
+}
+
+// FindErasureReceipt returns the erasure receipt of the user, ErrNoRowsFound when the user has not been erased
+func FindErasureReceipt(ctx context.Context, exec boil.ContextExecutor, userID string) (*models.ErasureReceipt, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindErasureReceipt")
+	defer span.End()
+
+	receipts, err := models.ErasureReceipts(
+		models.ErasureReceiptWhere.UserID.EQ(userID),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+
+	if len(receipts) == 0 {
+		return nil, ErrNoRowsFound
+	}
+	return receipts[0], nil
+}
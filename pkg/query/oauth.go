@@ -0,0 +1,307 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// CreateOAuthClient inserts the OAuth2 client, the hash of its secret has to be computed by the caller
+func CreateOAuthClient(ctx context.Context, exec boil.ContextExecutor, client *models.OauthClient) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateOAuthClient")
+	defer span.End()
+
+	if err := client.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+
+	logging.Ctx(ctx).Info().Msg("inserted successfully")
+	return nil
+}
+
+// FindOAuthClient returns the OAuth2 client, revoked included, ErrNoRowsFound when there is none
+func FindOAuthClient(ctx context.Context, exec boil.ContextExecutor, id string) (*models.OauthClient, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindOAuthClient")
+	defer span.End()
+
+	client, err := models.FindOauthClient(ctx, exec, id)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, ErrNoRowsFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return client, nil
+}
+
+// ListOAuthClients returns the OAuth2 clients that have not been revoked, the oldest first
+func ListOAuthClients(ctx context.Context, exec boil.ContextExecutor) (models.OauthClientSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListOAuthClients")
+	defer span.End()
+
+	clients, err := models.OauthClients(
+		models.OauthClientWhere.RevokedAt.IsNull(),
+		qm.OrderBy(models.OauthClientColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return clients, nil
+}
+
+// RevokeOAuthClient revokes the OAuth2 client and its refresh tokens, ErrNoRowsFound when there is no such client or it
+// is already revoked
+func RevokeOAuthClient(ctx context.Context, exec boil.ContextExecutor, id string, revokedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "RevokeOAuthClient")
+	defer span.End()
+
+	revoked, err := models.OauthClients(
+		models.OauthClientWhere.ID.EQ(id),
+		models.OauthClientWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.OauthClientColumns.RevokedAt: null.TimeFrom(revokedAt)})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	if revoked == 0 {
+		return ErrNoRowsFound
+	}
+
+	_, err = models.OauthRefreshTokens(
+		models.OauthRefreshTokenWhere.ClientID.EQ(id),
+		models.OauthRefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.OauthRefreshTokenColumns.RevokedAt: null.TimeFrom(revokedAt)})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	return nil
+}
+
+// CreateRefreshToken inserts the refresh token, its hash has to be computed by the caller
+func CreateRefreshToken(ctx context.Context, exec boil.ContextExecutor, token *models.OauthRefreshToken) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateRefreshToken")
+	defer span.End()
+
+	if err := token.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+	return nil
+}
+
+// FindRefreshTokenByHash returns the refresh token having the hash, revoked and expired included, ErrNoRowsFound when
+// there is none. The row is locked until the end of the transaction, a refresh token is only rotated once.
+func FindRefreshTokenByHash(ctx context.Context, exec boil.ContextExecutor, hash []byte) (*models.OauthRefreshToken, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindRefreshTokenByHash")
+	defer span.End()
+
+	token, err := models.OauthRefreshTokens(
+		models.OauthRefreshTokenWhere.TokenHash.EQ(hash),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, ErrNoRowsFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return token, nil
+}
+
+// RevokeRefreshToken revokes the refresh token, revoking a revoked token keeps its first revocation time
+func RevokeRefreshToken(ctx context.Context, exec boil.ContextExecutor, token *models.OauthRefreshToken, revokedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "RevokeRefreshToken")
+	defer span.End()
+
+	if token.RevokedAt.Valid {
+		return nil
+	}
+	token.RevokedAt = null.TimeFrom(revokedAt)
+	if _, err := token.Update(ctx, exec, boil.Whitelist(models.OauthRefreshTokenColumns.RevokedAt)); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	return nil
+}
+
+// RevokeSessionRefreshTokens revokes the refresh tokens bound to the session
+func RevokeSessionRefreshTokens(ctx context.Context, exec boil.ContextExecutor, sessionID string, revokedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "RevokeSessionRefreshTokens")
+	defer span.End()
+
+	_, err := models.OauthRefreshTokens(
+		models.OauthRefreshTokenWhere.SessionID.EQ(sessionID),
+		models.OauthRefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.OauthRefreshTokenColumns.RevokedAt: null.TimeFrom(revokedAt)})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	return nil
+}
+
+// DeleteUserRefreshTokens deletes every refresh token issued for the user, revoked included
+func DeleteUserRefreshTokens(ctx context.Context, exec boil.ContextExecutor, userID string) error {
+	ctx, exec, span := instrument(ctx, exec, "DeleteUserRefreshTokens")
+	defer span.End()
+
+	if _, err := models.OauthRefreshTokens(models.OauthRefreshTokenWhere.UserID.EQ(userID)).DeleteAll(ctx, exec); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("deletion failed")
+		return err
+	}
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token issued for the user
+func RevokeUserRefreshTokens(ctx context.Context, exec boil.ContextExecutor, userID string, revokedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "RevokeUserRefreshTokens")
+	defer span.End()
+
+	_, err := models.OauthRefreshTokens(
+		models.OauthRefreshTokenWhere.UserID.EQ(userID),
+		models.OauthRefreshTokenWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, models.M{models.OauthRefreshTokenColumns.RevokedAt: null.TimeFrom(revokedAt)})
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("revocation failed")
+		return err
+	}
+	return nil
+}
+
+// FindConsent returns the consent the user gave to the client, ErrNoRowsFound when there is none
+func FindConsent(ctx context.Context, exec boil.ContextExecutor, userID, clientID string) (*models.OauthConsent, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindConsent")
+	defer span.End()
+
+	consent, err := models.FindOauthConsent(ctx, exec, userID, clientID)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, ErrNoRowsFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return consent, nil
+}
+
+// SaveConsent inserts the consent or replaces the scopes of the existing one
+func SaveConsent(ctx context.Context, exec boil.ContextExecutor, consent *models.OauthConsent) error {
+	ctx, exec, span := instrument(ctx, exec, "SaveConsent")
+	defer span.End()
+
+	err := consent.Upsert(ctx, exec, true,
+		[]string{models.OauthConsentColumns.UserID, models.OauthConsentColumns.ClientID},
+		boil.Whitelist(models.OauthConsentColumns.Scopes, models.OauthConsentColumns.UpdatedAt),
+		boil.Infer(),
+	)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("upsert failed")
+		return err
+	}
+	return nil
+}
+
+// ListUserConsents returns the consents the user gave to the clients
+func ListUserConsents(ctx context.Context, exec boil.ContextExecutor, userID string) (models.OauthConsentSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListUserConsents")
+	defer span.End()
+
+	consents, err := models.OauthConsents(
+		models.OauthConsentWhere.UserID.EQ(userID),
+		qm.OrderBy(models.OauthConsentColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return consents, nil
+}
+
+// DeleteUserConsents deletes the consents the user gave to every client
+func DeleteUserConsents(ctx context.Context, exec boil.ContextExecutor, userID string) error {
+	ctx, exec, span := instrument(ctx, exec, "DeleteUserConsents")
+	defer span.End()
+
+	if _, err := models.OauthConsents(models.OauthConsentWhere.UserID.EQ(userID)).DeleteAll(ctx, exec); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("deletion failed")
+		return err
+	}
+	return nil
+}
+
+// CreateAuthorizationCode inserts the authorization code, its hash has to be computed by the caller
+func CreateAuthorizationCode(ctx context.Context, exec boil.ContextExecutor, code *models.OauthAuthorizationCode) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateAuthorizationCode")
+	defer span.End()
+
+	if err := code.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+	return nil
+}
+
+// FindAuthorizationCodeByHash returns the authorization code having the hash, used and expired included,
+// ErrNoRowsFound when there is none. The row is locked until the end of the transaction, a code is only used once.
+func FindAuthorizationCodeByHash(ctx context.Context, exec boil.ContextExecutor, hash []byte) (*models.OauthAuthorizationCode, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindAuthorizationCodeByHash")
+	defer span.End()
+
+	code, err := models.OauthAuthorizationCodes(
+		models.OauthAuthorizationCodeWhere.CodeHash.EQ(hash),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, ErrNoRowsFound
+		}
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return code, nil
+}
+
+// UseAuthorizationCode records that the code has been exchanged for the tokens of the session
+func UseAuthorizationCode(ctx context.Context, exec boil.ContextExecutor, code *models.OauthAuthorizationCode, sessionID string, usedAt time.Time) error {
+	ctx, exec, span := instrument(ctx, exec, "UseAuthorizationCode")
+	defer span.End()
+
+	code.UsedAt = null.TimeFrom(usedAt)
+	code.SessionID = null.StringFrom(sessionID)
+	_, err := code.Update(ctx, exec, boil.Whitelist(
+		models.OauthAuthorizationCodeColumns.UsedAt,
+		models.OauthAuthorizationCodeColumns.SessionID,
+	))
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("update failed")
+		return err
+	}
+	return nil
+}
+
+// DeleteExpiredAuthorizationCodes deletes the authorization codes that expired before the provided time and returns
+// how many were deleted
+func DeleteExpiredAuthorizationCodes(ctx context.Context, exec boil.ContextExecutor, before time.Time) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "DeleteExpiredAuthorizationCodes")
+	defer span.End()
+
+	deleted, err := models.OauthAuthorizationCodes(
+		models.OauthAuthorizationCodeWhere.ExpiresAt.LT(before),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("deletion failed")
+		return 0, err
+	}
+	return deleted, nil
+}
@@ -0,0 +1,214 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// LastOutboxEvent locks and returns the most recent outbox event, nil when the outbox is empty. Concurrent writers
+// serialize on the lock so that the seq order is the commit order, the conflicts are retried by conn.ExecuteTx.
+func LastOutboxEvent(ctx context.Context, exec boil.ContextExecutor) (*models.Outbox, error) {
+	ctx, exec, span := instrument(ctx, exec, "LastOutboxEvent")
+	defer span.End()
+
+	event, err := models.Outboxes(
+		qm.OrderBy(models.OutboxColumns.Seq+" DESC"),
+		qm.Limit(1),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return event, nil
+}
+
+// FirstOutboxSeq returns the smallest seq still in the outbox, 0 when the outbox is empty
+func FirstOutboxSeq(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "FirstOutboxSeq")
+	defer span.End()
+
+	return outboxSeq(ctx, exec, models.OutboxColumns.Seq)
+}
+
+// LastOutboxSeq returns the greatest seq in the outbox without locking it, 0 when the outbox is empty
+func LastOutboxSeq(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "LastOutboxSeq")
+	defer span.End()
+
+	return outboxSeq(ctx, exec, models.OutboxColumns.Seq+" DESC")
+}
+
+func outboxSeq(ctx context.Context, exec boil.ContextExecutor, orderBy string) (int64, error) {
+	event, err := models.Outboxes(
+		qm.Select(models.OutboxColumns.Seq),
+		qm.OrderBy(orderBy),
+		qm.Limit(1),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return 0, nil
+		}
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return 0, err
+	}
+	return event.Seq, nil
+}
+
+// ListOutboxEvents returns at most limit events with a seq greater than afterSeq, in seq order. When types are given
+// only the events of these types are returned.
+func ListOutboxEvents(ctx context.Context, exec boil.ContextExecutor, afterSeq int64, types []string, limit int) (models.OutboxSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListOutboxEvents")
+	defer span.End()
+
+	var queryMod = []qm.QueryMod{
+		models.OutboxWhere.Seq.GT(afterSeq),
+		qm.OrderBy(models.OutboxColumns.Seq),
+		qm.Limit(limit),
+	}
+	if len(types) > 0 {
+		queryMod = append(queryMod, models.OutboxWhere.EventType.IN(types))
+	}
+
+	events, err := models.Outboxes(queryMod...).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return events, nil
+}
+
+// CreateOutboxEvent writes the event to the outbox
+func CreateOutboxEvent(ctx context.Context, exec boil.ContextExecutor, event *models.Outbox) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateOutboxEvent")
+	defer span.End()
+
+	if err := event.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+	return nil
+}
+
+// CreateWebhookDelivery schedules the delivery of an outbox event to an endpoint
+func CreateWebhookDelivery(ctx context.Context, exec boil.ContextExecutor, delivery *models.WebhookDelivery) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateWebhookDelivery")
+	defer span.End()
+
+	if err := delivery.Insert(ctx, exec, boil.Infer()); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
+		return err
+	}
+	return nil
+}
+
+// ClaimDueDeliveries locks at most limit deliveries in the given status that are due at the provided time and pushes
+// their next attempt to claimedUntil, so that another dispatcher does not pick them up while they are being delivered.
+// The outbox events are loaded with the deliveries.
+func ClaimDueDeliveries(ctx context.Context, exec boil.ContextExecutor, status string, now, claimedUntil time.Time, limit int) (models.WebhookDeliverySlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ClaimDueDeliveries")
+	defer span.End()
+
+	deliveries, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.Status.EQ(status),
+		models.WebhookDeliveryWhere.NextAttemptAt.LTE(now),
+		qm.OrderBy(models.WebhookDeliveryColumns.NextAttemptAt),
+		qm.Limit(limit),
+		qm.For("UPDATE"),
+		qm.Load(models.WebhookDeliveryRels.Outbox),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	if _, err = deliveries.UpdateAll(ctx, exec, models.M{
+		models.WebhookDeliveryColumns.NextAttemptAt: claimedUntil,
+	}); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("update failed")
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// FindWebhookDelivery returns the delivery having the id, ErrNoRowsFound when there is none
+func FindWebhookDelivery(ctx context.Context, exec boil.ContextExecutor, id string) (*models.WebhookDelivery, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindWebhookDelivery")
+	defer span.End()
+
+	deliveries, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.ID.EQ(id),
+		qm.Load(models.WebhookDeliveryRels.Outbox),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, ErrNoRowsFound
+	}
+	return deliveries[0], nil
+}
+
+// ListWebhookDeliveries returns a page of the deliveries in the given status, the oldest first
+func ListWebhookDeliveries(ctx context.Context, exec boil.ContextExecutor, status string, offset, limit int) (models.WebhookDeliverySlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListWebhookDeliveries")
+	defer span.End()
+
+	deliveries, err := models.WebhookDeliveries(
+		models.WebhookDeliveryWhere.Status.EQ(status),
+		qm.OrderBy(models.WebhookDeliveryColumns.CreatedAt+", "+models.WebhookDeliveryColumns.ID),
+		qm.Offset(offset),
+		qm.Limit(limit),
+		qm.Load(models.WebhookDeliveryRels.Outbox),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// UpdateWebhookDelivery writes the given columns of the delivery
+func UpdateWebhookDelivery(ctx context.Context, exec boil.ContextExecutor, delivery *models.WebhookDelivery, columnsUpdated []string) error {
+	ctx, exec, span := instrument(ctx, exec, "UpdateWebhookDelivery")
+	defer span.End()
+
+	if _, err := delivery.Update(ctx, exec, boil.Whitelist(columnsUpdated...)); err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("update failed")
+		return err
+	}
+	return nil
+}
+
+// PruneOutbox deletes the oldest outbox events, up to the first one created after the provided time or having a
+// delivery that has not been delivered, the deliveries are deleted with them. Only a prefix of the seqs is pruned, so
+// that a cursor below the first remaining event is known to have missed events. The most recent event is always kept,
+// the next seq is derived from it.
+func PruneOutbox(ctx context.Context, exec boil.ContextExecutor, before time.Time, deliveredStatus string) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "PruneOutbox")
+	defer span.End()
+
+	pruned, err := models.Outboxes(
+		qm.Where(`seq < (SELECT max(seq) FROM outbox)`),
+		qm.Where(`NOT EXISTS (SELECT 1 FROM outbox o WHERE o.seq <= outbox.seq AND (o.created_at >= ? OR EXISTS (
+			SELECT 1 FROM webhook_deliveries d WHERE d.outbox_id = o.id AND d.status != ?)))`, before, deliveredStatus),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("prune failed")
+		return 0, err
+	}
+	return pruned, nil
+}
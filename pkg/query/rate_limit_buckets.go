@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"time"
+	"user.app/models"
+	"user.app/pkg/logging"
+)
+
+// LockRateLimitBucket locks and returns the bucket, nil when there is none. The concurrent calls of a client
+// serialize on the lock, the conflicts are retried by conn.ExecuteTx.
+func LockRateLimitBucket(ctx context.Context, exec boil.ContextExecutor, id string) (*models.RateLimitBucket, error) {
+	ctx, exec, span := instrument(ctx, exec, "LockRateLimitBucket")
+	defer span.End()
+
+	bucket, err := models.RateLimitBuckets(
+		models.RateLimitBucketWhere.ID.EQ(id),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, nil
+		}
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return bucket, nil
+}
+
+// SaveRateLimitBucket writes the bucket, its updated_at is the time of the call being limited and not the time of
+// the statement
+func SaveRateLimitBucket(ctx context.Context, exec boil.ContextExecutor, bucket *models.RateLimitBucket) error {
+	ctx, exec, span := instrument(ctx, exec, "SaveRateLimitBucket")
+	defer span.End()
+
+	err := bucket.Upsert(boil.SkipTimestamps(ctx), exec, true, []string{models.RateLimitBucketColumns.ID},
+		boil.Whitelist(models.RateLimitBucketColumns.Tokens, models.RateLimitBucketColumns.UpdatedAt), boil.Infer())
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("upsert failed")
+		return err
+	}
+	return nil
+}
+
+// PruneRateLimitBuckets deletes the buckets untouched since the provided time
+func PruneRateLimitBuckets(ctx context.Context, exec boil.ContextExecutor, before time.Time) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "PruneRateLimitBuckets")
+	defer span.End()
+
+	pruned, err := models.RateLimitBuckets(
+		models.RateLimitBucketWhere.UpdatedAt.LT(before),
+	).DeleteAll(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("prune failed")
+		return 0, err
+	}
+	return pruned, nil
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"context"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"user.app/models"
+	"user.app/pkg/constants"
+	"user.app/pkg/logging"
+)
+
+// FindServiceAccount returns the service account that has not been deleted, ErrNoRowsFound when there is none
+func FindServiceAccount(ctx context.Context, exec boil.ContextExecutor, id string) (*models.User, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindServiceAccount")
+	defer span.End()
+
+	accounts, err := models.Users(
+		models.UserWhere.ID.EQ(id),
+		models.UserWhere.PrincipalType.EQ(constants.PrincipalService),
+		models.UserWhere.DeletedAt.EQ(NotDeleted),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, ErrNoRowsFound
+	}
+	return accounts[0], nil
+}
+
+// FindServiceAccountBySAN returns the service account that has not been deleted and has one of the SANs,
+// ErrNoRowsFound when there is none
+func FindServiceAccountBySAN(ctx context.Context, exec boil.ContextExecutor, sans []string) (*models.User, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindServiceAccountBySAN")
+	defer span.End()
+
+	if len(sans) == 0 {
+		return nil, ErrNoRowsFound
+	}
+	values := make([]interface{}, 0, len(sans))
+	for _, san := range sans {
+		values = append(values, san)
+	}
+
+	accounts, err := models.Users(
+		models.UserWhere.PrincipalType.EQ(constants.PrincipalService),
+		models.UserWhere.DeletedAt.EQ(NotDeleted),
+		qm.WhereIn(models.UserColumns.CertificateSan+" IN ?", values...),
+		qm.OrderBy(models.UserColumns.CreatedAt),
+		qm.Limit(1),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, ErrNoRowsFound
+	}
+	return accounts[0], nil
+}
+
+// ListServiceAccounts returns at most limit service accounts that have not been deleted, the oldest first
+func ListServiceAccounts(ctx context.Context, exec boil.ContextExecutor, offset, limit int) (models.UserSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListServiceAccounts")
+	defer span.End()
+
+	accounts, err := models.Users(
+		models.UserWhere.PrincipalType.EQ(constants.PrincipalService),
+		models.UserWhere.DeletedAt.EQ(NotDeleted),
+		qm.OrderBy(models.UserColumns.CreatedAt+", "+models.UserColumns.ID),
+		qm.Offset(offset),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("retrieval failed")
+		return nil, err
+	}
+	return accounts, nil
+}
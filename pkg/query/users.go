@@ -5,35 +5,53 @@ import (
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/reiver/go-pqerror"
-	"github.com/rs/zerolog/log"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 	"user.app/models"
+	"user.app/pkg/logging"
+	"user.app/pkg/normalize"
+	"user.app/pkg/tracing"
 )
 
 var (
 	ErrMultipleUsersWithSameUsernameEmail = errors.New("multiple users with the same user and Email found")
 	ErrNoRowsFound                        = errors.New("no rows were found")
+	ErrUsernameOrEmailTaken               = errors.New("username or email already taken")
+)
+
+// NotDeleted is the deleted_at sentinel of the users that have not been deleted
+var NotDeleted = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	// usernameNormalized is the computed, lower cased username column. It is blacklisted in sqlboiler.toml because
+	// computed columns cannot be written.
+	usernameNormalized = "username_normalized"
+	// emailNormalized is the computed, lower cased email column
+	emailNormalized = "email_normalized"
 )
 
 func CreateUser(ctx context.Context, exec boil.ContextExecutor, user *models.User) error {
+	ctx, exec, span := instrument(ctx, exec, "CreateUser")
+	defer span.End()
+
 	err := user.Insert(ctx, exec, boil.Infer())
 	if err != nil {
-		log.Error().Err(err).Msg("insertion failed")
+		logging.Ctx(ctx).Error().Err(err).Msg("insertion failed")
 
 		pqError, ok := errors.Cause(err).(*pq.Error)
 		if ok {
 			switch pqError.Code {
 			case pqerror.CodeIntegrityConstraintViolationUniqueViolation:
-				log.Error().Str("name", "already exists").Str("Email", "already exists").Msg("insertion failed")
-				return errors.Wrap(err, "unique constraint violation for name and Email")
+				logging.Ctx(ctx).Error().Str("name", "already exists").Str("Email", "already exists").Msg("insertion failed")
+				return errors.Wrap(ErrUsernameOrEmailTaken, pqError.Message)
 			}
 		}
 		return err
 	}
 
-	log.Info().Msg("inserted successfully")
+	logging.Ctx(ctx).Info().Msg("inserted successfully")
 	return nil
 }
 
@@ -48,21 +66,32 @@ func (f *UserFilter) apply(mod []qm.QueryMod) []qm.QueryMod {
 		return mod
 	}
 	if len(f.Username) > 0 {
-		mod = append(mod, models.UserWhere.Username.EQ(f.Username))
+		username, err := normalize.Username(f.Username)
+		if err != nil {
+			username = f.Username
+		}
+		mod = append(mod, qm.Where(usernameNormalized+" = lower(?)", username))
 	}
 	if len(f.ID) > 0 {
 		mod = append(mod, models.UserWhere.ID.EQ(f.ID))
 	}
 	if len(f.Email) > 0 {
-		mod = append(mod, models.UserWhere.Username.EQ(f.Email))
+		email, err := normalize.Email(f.Email)
+		if err != nil {
+			email = f.Email
+		}
+		mod = append(mod, qm.Where(emailNormalized+" = lower(?)", email))
 	}
 
 	return mod
 }
 
 func FindUser(ctx context.Context, exec boil.ContextExecutor, filter *UserFilter) (*models.User, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindUser")
+	defer span.End()
+
 	var queryMod = []qm.QueryMod{
-		models.UserWhere.DeletedAt.EQ(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)),
+		models.UserWhere.DeletedAt.EQ(NotDeleted),
 	}
 
 	if filter != nil {
@@ -74,40 +103,190 @@ func FindUser(ctx context.Context, exec boil.ContextExecutor, filter *UserFilter
 	).All(ctx, exec)
 
 	if err != nil {
-		log.Error().Msg("retrieval failed")
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
 		return nil, err
 	}
 
 	if len(userSlice) == 0 {
-		log.Error().Msg("no results")
+		logging.Ctx(ctx).Error().Msg("no results")
 		return nil, ErrNoRowsFound
 	}
 
 	if len(userSlice) > 1 {
-		log.Error().Msg("multiple users found")
+		logging.Ctx(ctx).Error().Msg("multiple users found")
 		return nil, ErrMultipleUsersWithSameUsernameEmail
 	}
 
-	log.Info().Msg("retrieval successful")
+	logging.Ctx(ctx).Info().Msg("retrieval successful")
 	return userSlice[0], nil
 }
 
+// FindRestorableUsers returns the users matching the filter that were deleted after the since time and have not been
+// anonymized, the most recently deleted first
+func FindRestorableUsers(ctx context.Context, exec boil.ContextExecutor, filter *UserFilter, since time.Time) (models.UserSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "FindRestorableUsers")
+	defer span.End()
+
+	var queryMod = []qm.QueryMod{
+		models.UserWhere.DeletedAt.NEQ(NotDeleted),
+		models.UserWhere.DeletedAt.GT(since),
+		models.UserWhere.AnonymizedAt.IsNull(),
+		qm.OrderBy(models.UserColumns.DeletedAt + " DESC"),
+	}
+
+	if filter != nil {
+		queryMod = filter.apply(queryMod)
+	}
+
+	userSlice, err := models.Users(
+		queryMod...,
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return userSlice, nil
+}
+
+// ListExpiredUsers returns at most limit users that were deleted before the provided time and have not been
+// anonymized
+func ListExpiredUsers(ctx context.Context, exec boil.ContextExecutor, before time.Time, limit int) (models.UserSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListExpiredUsers")
+	defer span.End()
+
+	userSlice, err := models.Users(
+		models.UserWhere.DeletedAt.NEQ(NotDeleted),
+		models.UserWhere.DeletedAt.LT(before),
+		models.UserWhere.AnonymizedAt.IsNull(),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return userSlice, nil
+}
+
+// IsRecentlyDeleted reports whether a user deleted after the since time had the username or the email. It is used
+// to hold the usernames and emails of deleted users back for the reuse cooldown.
+func IsRecentlyDeleted(ctx context.Context, exec boil.ContextExecutor, username, email string, since time.Time) (bool, error) {
+	ctx, exec, span := instrument(ctx, exec, "IsRecentlyDeleted")
+	defer span.End()
+
+	var identity []qm.QueryMod
+	if len(username) > 0 {
+		identity = append(identity, qm.Where(usernameNormalized+" = lower(?)", username))
+	}
+	if len(email) > 0 {
+		identity = append(identity, qm.Or(emailNormalized+" = lower(?)", email))
+	}
+	if len(identity) == 0 {
+		return false, nil
+	}
+
+	exists, err := models.Users(
+		models.UserWhere.DeletedAt.NEQ(NotDeleted),
+		models.UserWhere.DeletedAt.GT(since),
+		qm.Expr(identity...),
+	).Exists(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("recently deleted check failed")
+		return false, err
+	}
+	return exists, nil
+}
+
+// PurgeDeletedUsers hard deletes the users that were soft deleted before the provided time. They are deleted one by
+// one, so that the delete hooks publish their events in the transaction of exec.
+func PurgeDeletedUsers(ctx context.Context, exec boil.ContextExecutor, before time.Time) (int64, error) {
+	ctx, exec, span := instrument(ctx, exec, "PurgeDeletedUsers")
+	defer span.End()
+
+	users, err := models.Users(
+		models.UserWhere.DeletedAt.NEQ(NotDeleted),
+		models.UserWhere.DeletedAt.LT(before),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Err(err).Msg("purge failed")
+		return 0, err
+	}
+
+	var purged int64
+	for _, user := range users {
+		deleted, err := user.Delete(ctx, exec)
+		if err != nil {
+			logging.Ctx(ctx).Error().Err(err).Str("user_id", user.ID).Msg("purge failed")
+			return purged, err
+		}
+		purged += deleted
+	}
+
+	logging.Ctx(ctx).Info().Int64("purged", purged).Msg("purged successfully")
+	return purged, nil
+}
+
+// ListUsers returns all the users that have not been deleted
+func ListUsers(ctx context.Context, exec boil.ContextExecutor) (models.UserSlice, error) {
+	ctx, exec, span := instrument(ctx, exec, "ListUsers")
+	defer span.End()
+
+	userSlice, err := models.Users(
+		models.UserWhere.DeletedAt.EQ(NotDeleted),
+		qm.OrderBy(models.UserColumns.CreatedAt),
+	).All(ctx, exec)
+	if err != nil {
+		logging.Ctx(ctx).Error().Msg("retrieval failed")
+		return nil, err
+	}
+	return userSlice, nil
+}
+
+// changedColumnsKey is the context key of the columns written by UpdateUser
+type changedColumnsKey struct{}
+
+// ChangedColumns returns the columns written by the UpdateUser call the context belongs to, it lets the update hooks
+// know what changed
+func ChangedColumns(ctx context.Context) []string {
+	columns, _ := ctx.Value(changedColumnsKey{}).([]string)
+	return columns
+}
+
 func UpdateUser(ctx context.Context, exec boil.ContextExecutor, user *models.User, columnsUpdated []string) error {
+	ctx, exec, span := instrument(ctx, exec, "UpdateUser")
+	defer span.End()
+
+	ctx = context.WithValue(ctx, changedColumnsKey{}, columnsUpdated)
 	_, err := user.Update(ctx, exec, boil.Whitelist(append(columnsUpdated, "updated_at")...))
 	if err != nil {
-		log.Error().Err(err).Msg("update failed")
+		logging.Ctx(ctx).Error().Err(err).Msg("update failed")
 
 		pqError, ok := errors.Cause(err).(*pq.Error)
 		if ok {
 			switch pqError.Code {
 			case pqerror.CodeIntegrityConstraintViolationUniqueViolation:
-				log.Error().Str("name", "already exists").Str("Email", "already exists").Msg("insertion failed")
-				return errors.Wrap(err, "unique constraint violation for name and Email")
+				logging.Ctx(ctx).Error().Str("name", "already exists").Str("Email", "already exists").Msg("insertion failed")
+				return errors.Wrap(ErrUsernameOrEmailTaken, pqError.Message)
 			}
 		}
 		return err
 	}
 
-	log.Info().Msg("updated successfully")
+	logging.Ctx(ctx).Info().Msg("updated successfully")
 	return nil
 }
+
+// instrumentedExecutor traces and logs the statements, the queries called by the hooks of another query get it back
+// and do not wrap it again
+type instrumentedExecutor struct {
+	boil.ContextExecutor
+}
+
+// instrument starts the span of a query, the statements it runs through the returned executor are its children and
+// are logged at debug level
+func instrument(ctx context.Context, exec boil.ContextExecutor, name string) (context.Context, boil.ContextExecutor, trace.Span) {
+	ctx, span := tracing.Start(ctx, "query."+name)
+	if _, ok := exec.(instrumentedExecutor); !ok {
+		exec = instrumentedExecutor{ContextExecutor: logging.Executor(tracing.Executor(exec))}
+	}
+	return ctx, exec, span
+}
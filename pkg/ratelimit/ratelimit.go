@@ -0,0 +1,276 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"math"
+	"time"
+	"user.app/pkg/constants"
+	"user.app/pkg/logging"
+	"user.app/pkg/transport"
+)
+
+const (
+	// KeyPeer and the other keys tell whose bucket a call takes its token from
+	KeyPeer    = "peer"
+	KeyUser    = "user"
+	KeyAPIKey  = "api_key"
+	KeyAccount = "account"
+
+	// StoreMemory and StoreCockroachDB are the values of rate_limit.store
+	StoreMemory      = "memory"
+	StoreCockroachDB = "cockroachdb"
+
+	// pruneInterval is how often the buckets that would be full again are dropped
+	pruneInterval = time.Minute
+)
+
+type (
+	// Rule limits the calls of a method, or the requests of an HTTP path, to Rate per second for each client, with
+	// bursts of up to Burst calls
+	Rule struct {
+		Method string
+		Rate   float64
+		Burst  int
+		// Key is peer, the IP of the client, user, the authenticated user or service account, api_key, the API key
+		// the client authenticated with, or account, the username or the OAuth2 client the HTTP requests present
+		// credentials for. The calls without a user or an API key fall back to the next key, the gRPC calls use user
+		// for account. A method can have one rule per key.
+		Key string
+	}
+
+	// Client is the caller of an HTTP endpoint
+	Client struct {
+		// IP is the address of the HTTP peer
+		IP string
+		// Account is the username or the client_id the request presents credentials for, empty when there is none
+		Account string
+	}
+
+	// Config is the [rate_limit] section of the configuration
+	Config struct {
+		Enabled bool
+		// Store is memory, the buckets of each node, or cockroachdb, the buckets shared by the nodes of a cluster
+		Store   string
+		Methods []Rule
+	}
+
+	// Store holds the token buckets
+	Store interface {
+		// Take removes a token from the bucket, refilled at the rate of the rule since it was last taken from. It
+		// returns 0 when it had one, how long until the next one otherwise.
+		Take(ctx context.Context, bucket string, rule Rule, now time.Time) (time.Duration, error)
+
+		// Prune drops the buckets untouched since the provided time
+		Prune(ctx context.Context, before time.Time) error
+	}
+
+	// Limiter applies the rules of the methods, the calls of the other methods are not limited
+	Limiter struct {
+		rules map[string][]Rule
+		store Store
+		// idle is how long a bucket takes to fill up again, it is not needed anymore after that
+		idle time.Duration
+	}
+)
+
+// LoadConfig reads and validates the [rate_limit] section of the configuration
+func LoadConfig() (Config, error) {
+	c := Config{Store: StoreMemory}
+	if err := viper.UnmarshalKey("rate_limit", &c); err != nil {
+		return c, errors.Wrap(err, "cannot read rate_limit")
+	}
+	if c.Store != StoreMemory && c.Store != StoreCockroachDB {
+		return c, errors.Errorf("unsupported rate_limit.store %q, it must be memory or cockroachdb", c.Store)
+	}
+	methods := make(map[string]bool, len(c.Methods))
+	for i, rule := range c.Methods {
+		if len(rule.Method) == 0 {
+			return c, errors.Errorf("rate_limit.methods[%d] has no method", i)
+		}
+		if rule.Rate <= 0 || rule.Burst < 1 {
+			return c, errors.Errorf("rate limit of %s must have a positive rate and burst", rule.Method)
+		}
+		switch rule.Key {
+		case "":
+			c.Methods[i].Key = KeyPeer
+		case KeyPeer, KeyUser, KeyAPIKey, KeyAccount:
+		default:
+			return c, errors.Errorf("unsupported key %q for %s, it must be peer, user, api_key or account", rule.Key,
+				rule.Method)
+		}
+		if methods[rule.Method+" "+c.Methods[i].Key] {
+			return c, errors.Errorf("rate limit of %s by %s is configured twice", rule.Method, c.Methods[i].Key)
+		}
+		methods[rule.Method+" "+c.Methods[i].Key] = true
+	}
+	return c, nil
+}
+
+// New returns the Limiter of the configuration, it limits no call when the rate limiting is disabled
+func New(c Config, db *sql.DB) *Limiter {
+	if !c.Enabled {
+		return NewLimiter(nil, nil)
+	}
+	if c.Store == StoreCockroachDB {
+		return NewLimiter(c.Methods, NewDBStore(db))
+	}
+	return NewLimiter(c.Methods, NewMemoryStore())
+}
+
+// NewLimiter is the constructor for the Limiter
+func NewLimiter(rules []Rule, store Store) *Limiter {
+	l := &Limiter{
+		rules: make(map[string][]Rule, len(rules)),
+		store: store,
+	}
+	for _, rule := range rules {
+		l.rules[rule.Method] = append(l.rules[rule.Method], rule)
+		if fill := time.Duration(float64(rule.Burst) / rule.Rate * float64(time.Second)); fill > l.idle {
+			l.idle = fill
+		}
+	}
+	return l
+}
+
+// UnaryServerInterceptor rejects the calls over the limit with ResourceExhausted, the RetryInfo detail tells when to
+// retry. It comes after the authentication, the user and API key are known then.
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor limits the streams when they are opened
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.allow(stream.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// Run prunes the buckets that are full again until the context is done
+func (l *Limiter) Run(ctx context.Context) error {
+	if len(l.rules) == 0 {
+		return nil
+	}
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// a pass is not interrupted by the shutdown
+			if err := l.store.Prune(context.Background(), time.Now().Add(-l.idle)); err != nil {
+				logging.Ctx(ctx).Error().Err(err).Msg("cannot prune the rate limit buckets")
+			}
+		}
+	}
+}
+
+// Wait takes a token from the buckets of the client for the method, an HTTP path. It returns how long until the
+// request would be allowed, 0 when it is. The HTTP requests are not authenticated, the rules by user and API key use
+// the IP and the rules by account are skipped when the request has no account.
+func (l *Limiter) Wait(ctx context.Context, method string, client Client) time.Duration {
+	return l.take(ctx, method, func(key string) string {
+		if key != KeyAccount {
+			return "ip:" + client.IP
+		}
+		if len(client.Account) == 0 {
+			return ""
+		}
+		// the accounts are not validated yet, their hash bounds the size of the bucket
+		sum := sha256.Sum256([]byte(client.Account))
+		return "account:" + hex.EncodeToString(sum[:])
+	})
+}
+
+// take takes a token from the bucket of every rule of the method, client returns the bucket of the client for the
+// key of a rule, the rule is skipped when it is empty. The longest wait is returned.
+func (l *Limiter) take(ctx context.Context, method string, client func(key string) string) time.Duration {
+	var longest time.Duration
+	for _, rule := range l.rules[method] {
+		bucket := client(rule.Key)
+		if len(bucket) == 0 {
+			continue
+		}
+		// the rules of a method have their own buckets, even when a key falls back to the IP
+		wait, err := l.store.Take(ctx, method+" "+rule.Key+" "+bucket, rule, time.Now())
+		if err != nil {
+			// the limiter fails open, its store being down must not take the service down with it
+			logging.Ctx(ctx).Error().Err(err).Msg("cannot take a rate limit token")
+			continue
+		}
+		if wait > longest {
+			longest = wait
+		}
+	}
+	return longest
+}
+
+func (l *Limiter) allow(ctx context.Context, fullMethod string) error {
+	wait := l.take(ctx, fullMethod, func(key string) string { return clientKey(ctx, key) })
+	if wait == 0 {
+		return nil
+	}
+
+	st, err := status.New(codes.ResourceExhausted, "rate limit exceeded").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(wait)})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return st.Err()
+}
+
+// clientKey identifies the client by the key of the rule, the next key is used when the client does not have one
+func clientKey(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if key == KeyAPIKey {
+		if values := md.Get(constants.MDKeyAPIKeyID); len(values) > 0 {
+			return "api-key:" + values[0]
+		}
+		key = KeyUser
+	}
+	if key == KeyUser || key == KeyAccount {
+		if values := md.Get(constants.MDKeyUserID); len(values) > 0 {
+			return "user:" + values[0]
+		}
+	}
+	return "ip:" + transport.ClientIP(ctx)
+}
+
+// take refills the bucket since it was last updated and removes a token from it, the remaining tokens are returned
+// with the wait for the next token when there was none
+func take(tokens float64, updatedAt time.Time, rule Rule, now time.Time) (float64, time.Duration) {
+	elapsed := now.Sub(updatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	tokens = math.Min(float64(rule.Burst), tokens+elapsed*rule.Rate)
+	if tokens >= 1 {
+		return tokens - 1, 0
+	}
+	wait := time.Duration((1 - tokens) / rule.Rate * float64(time.Second))
+	if wait < time.Millisecond {
+		// 0 means that the call is allowed
+		wait = time.Millisecond
+	}
+	return tokens, wait
+}
@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/viper"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+	"user.app/pkg/constants"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	store := NewMemoryStore()
+	rule := Rule{Method: "/message.UserApp/SignIn", Rate: 2, Burst: 3}
+	now := time.Unix(1600000000, 0)
+
+	for i := 0; i < 3; i++ {
+		if wait, _ := store.Take(context.Background(), "a", rule, now); wait != 0 {
+			t.Fatalf("call %d of the burst waits %s", i, wait)
+		}
+	}
+	if wait, _ := store.Take(context.Background(), "a", rule, now); wait != 500*time.Millisecond {
+		t.Fatalf("the call over the burst waits %s, expected 500ms", wait)
+	}
+	if wait, _ := store.Take(context.Background(), "b", rule, now); wait != 0 {
+		t.Fatalf("the bucket of another client waits %s", wait)
+	}
+	if wait, _ := store.Take(context.Background(), "a", rule, now.Add(500*time.Millisecond)); wait != 0 {
+		t.Fatalf("the refilled bucket waits %s", wait)
+	}
+
+	if err := store.Prune(context.Background(), now.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.buckets) != 0 {
+		t.Fatalf("%d buckets left after the prune", len(store.buckets))
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	const method = "/message.UserApp/SignIn"
+	limiter := NewLimiter([]Rule{{Method: method, Rate: 1, Burst: 1, Key: KeyUser}}, NewMemoryStore())
+	interceptor := limiter.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(userID, fullMethod string) error {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(constants.MDKeyUserID, userID))
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: fullMethod}, handler)
+		return err
+	}
+
+	if err := call("1", method); err != nil {
+		t.Fatal(err)
+	}
+	err := call("1", method)
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected the RetryInfo detail, got %v", st.Details())
+	}
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	if !ok {
+		t.Fatalf("expected the RetryInfo detail, got %T", st.Details()[0])
+	}
+	if delay, _ := ptypes.Duration(retryInfo.RetryDelay); delay <= 0 || delay > time.Second {
+		t.Fatalf("unexpected retry delay %s", delay)
+	}
+
+	if err := call("2", method); err != nil {
+		t.Fatalf("another user is limited: %v", err)
+	}
+	if err := call("1", "/message.UserApp/GetUser"); err != nil {
+		t.Fatalf("a method without a rule is limited: %v", err)
+	}
+}
+
+func TestWait(t *testing.T) {
+	const path = "/oauth/token"
+	limiter := NewLimiter([]Rule{
+		{Method: path, Rate: 1, Burst: 2, Key: KeyPeer},
+		{Method: path, Rate: 1, Burst: 1, Key: KeyAccount},
+	}, NewMemoryStore())
+	ctx := context.Background()
+
+	if wait := limiter.Wait(ctx, path, Client{IP: "192.0.2.1", Account: "uac_a"}); wait != 0 {
+		t.Fatalf("the first request waits %s", wait)
+	}
+	if wait := limiter.Wait(ctx, path, Client{IP: "192.0.2.2", Account: "uac_a"}); wait <= 0 || wait > time.Second {
+		t.Fatalf("the second request of the account from another IP waits %s", wait)
+	}
+	if wait := limiter.Wait(ctx, path, Client{IP: "192.0.2.1"}); wait != 0 {
+		t.Fatalf("the request without an account waits %s", wait)
+	}
+	if wait := limiter.Wait(ctx, path, Client{IP: "192.0.2.1", Account: "uac_b"}); wait <= 0 {
+		t.Fatal("the third request of the IP is allowed")
+	}
+	if wait := limiter.Wait(ctx, "/oauth/introspect", Client{IP: "192.0.2.1", Account: "uac_a"}); wait != 0 {
+		t.Fatalf("a path without a rule waits %s", wait)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	rule := func(method, key string) map[string]interface{} {
+		return map[string]interface{}{"method": method, "rate": 1, "burst": 1, "key": key}
+	}
+	tests := []struct {
+		name    string
+		methods []interface{}
+		valid   bool
+	}{
+		{"one key per method", []interface{}{rule("/oauth/token", "peer"), rule("/oauth/token", "account")}, true},
+		{"default key", []interface{}{rule("/oauth/token", ""), rule("/oauth/token", "account")}, true},
+		{"key twice", []interface{}{rule("/oauth/token", "account"), rule("/oauth/token", "account")}, false},
+		{"default key twice", []interface{}{rule("/oauth/token", ""), rule("/oauth/token", "peer")}, false},
+		{"unknown key", []interface{}{rule("/oauth/token", "client")}, false},
+	}
+	defer viper.Set("rate_limit", nil)
+	for _, tt := range tests {
+		viper.Set("rate_limit", map[string]interface{}{"methods": tt.methods})
+		if _, err := LoadConfig(); (err == nil) != tt.valid {
+			t.Errorf("%s: got %v, want valid %v", tt.name, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+	"user.app/models"
+	"user.app/pkg/conn"
+	"user.app/pkg/query"
+)
+
+type (
+	bucket struct {
+		tokens    float64
+		updatedAt time.Time
+	}
+
+	// MemoryStore keeps the buckets in memory, each node of a cluster limits the calls it receives on its own
+	MemoryStore struct {
+		mu      sync.Mutex
+		buckets map[string]*bucket
+	}
+
+	// DBStore keeps the buckets in CockroachDB, they are shared by the nodes of a cluster. Every limited call takes its
+	// token in a transaction.
+	DBStore struct {
+		db *sql.DB
+	}
+)
+
+// NewMemoryStore is the constructor for the MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take removes a token from the bucket, the buckets start full
+func (s *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+	var wait time.Duration
+	b.tokens, wait = take(b.tokens, b.updatedAt, rule, now)
+	b.updatedAt = now
+	return wait, nil
+}
+
+// Prune drops the buckets untouched since the provided time
+func (s *MemoryStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(before) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
+
+// NewDBStore is the constructor for the DBStore
+func NewDBStore(db *sql.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Take removes a token from the bucket locked in the transaction, the buckets start full
+func (s *DBStore) Take(ctx context.Context, key string, rule Rule, now time.Time) (time.Duration, error) {
+	var wait time.Duration
+	err := conn.ExecuteTx(ctx, s.db, func(tx *sql.Tx) error {
+		b, err := query.LockRateLimitBucket(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if b == nil {
+			b = &models.RateLimitBucket{ID: key, Tokens: float64(rule.Burst), UpdatedAt: now}
+		}
+		b.Tokens, wait = take(b.Tokens, b.UpdatedAt, rule, now)
+		b.UpdatedAt = now
+		return query.SaveRateLimitBucket(ctx, tx, b)
+	})
+	return wait, err
+}
+
+// Prune deletes the buckets untouched since the provided time
+func (s *DBStore) Prune(ctx context.Context, before time.Time) error {
+	_, err := query.PruneRateLimitBuckets(ctx, s.db, before)
+	return err
+}
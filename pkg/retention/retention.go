@@ -0,0 +1,157 @@
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"time"
+	"user.app/pkg/conn"
+	"user.app/pkg/erasure"
+	"user.app/pkg/query"
+)
+
+const (
+	// DefaultRetentionInHours is used when users.retention is not configured
+	DefaultRetentionInHours = 90 * 24
+	// DefaultPurgeIntervalInMinutes is used when users.purge_interval is not configured
+	DefaultPurgeIntervalInMinutes = 60
+	// DefaultGracePeriodInHours is used when users.restore_grace_period is not configured
+	DefaultGracePeriodInHours = 30 * 24
+
+	// ExpireByAnonymizing erases the personal data of a deleted user once its grace period is over, see
+	// erasure.EraseInTx, the row itself is purged after the retention period
+	ExpireByAnonymizing = "anonymize"
+	// ExpireByDeleting hard deletes a deleted user as soon as its grace period is over
+	ExpireByDeleting = "delete"
+
+	// expireBatchSize is the number of users expired in one transaction
+	expireBatchSize = 100
+)
+
+// ReuseCooldown is how long the username and email of a deleted user stay reserved before they can be registered
+// again
+func ReuseCooldown() time.Duration {
+	return time.Duration(viper.GetInt("users.reuse_cooldown")) * time.Hour
+}
+
+// Period is how long soft deleted users are kept before they are purged
+func Period() time.Duration {
+	hours := viper.GetInt("users.retention")
+	if hours <= 0 {
+		hours = DefaultRetentionInHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// GracePeriod is how long a deleted user can be restored, by its owner or by an admin
+func GracePeriod() time.Duration {
+	hours := viper.GetInt("users.restore_grace_period")
+	if hours <= 0 {
+		hours = DefaultGracePeriodInHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// ExpiryAction is what happens to a deleted user when its grace period is over, ExpireByAnonymizing or
+// ExpireByDeleting
+func ExpiryAction() string {
+	if viper.GetString("users.expiry_action") == ExpireByDeleting {
+		return ExpireByDeleting
+	}
+	return ExpireByAnonymizing
+}
+
+// Purger is the retention job. It periodically expires the deleted users whose grace period is over and hard deletes
+// the users that were soft deleted longer than the retention period ago.
+type Purger struct {
+	db       *sql.DB
+	interval time.Duration
+}
+
+// NewPurger is the constructor for the Purger
+func NewPurger(db *sql.DB) *Purger {
+	minutes := viper.GetInt("users.purge_interval")
+	if minutes <= 0 {
+		minutes = DefaultPurgeIntervalInMinutes
+	}
+	return &Purger{
+		db:       db,
+		interval: time.Duration(minutes) * time.Minute,
+	}
+}
+
+// Run purges on every interval until the context is done. Failed purges are logged and retried on the next tick. The
+// pass in progress is not canceled with the context, a shutdown waits for it.
+func (p *Purger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		pass := context.Background()
+		if _, err := p.ExpireOnce(pass); err != nil {
+			log.Error().Err(err).Msg("cannot expire the deleted users")
+		}
+		if _, err := p.PurgeOnce(pass); err != nil {
+			log.Error().Err(err).Msg("cannot purge the deleted users")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce hard deletes the users whose retention period is over and returns how many were deleted
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	var purged int64
+	err := conn.ExecuteTx(ctx, p.db, func(tx *sql.Tx) error {
+		var err error
+		purged, err = query.PurgeDeletedUsers(ctx, tx, time.Now().Add(-Period()))
+		return err
+	})
+	return purged, err
+}
+
+// ExpireOnce erases or deletes, depending on the ExpiryAction, the deleted users whose grace period is over. It
+// returns how many users were expired.
+func (p *Purger) ExpireOnce(ctx context.Context) (int64, error) {
+	before := time.Now().Add(-GracePeriod())
+	if ExpiryAction() == ExpireByDeleting {
+		var deleted int64
+		err := conn.ExecuteTx(ctx, p.db, func(tx *sql.Tx) error {
+			var err error
+			deleted, err = query.PurgeDeletedUsers(ctx, tx, before)
+			return err
+		})
+		return deleted, err
+	}
+
+	var erased int64
+	for {
+		var batch int
+		err := conn.ExecuteTx(ctx, p.db, func(tx *sql.Tx) error {
+			users, err := query.ListExpiredUsers(ctx, tx, before, expireBatchSize)
+			if err != nil {
+				return err
+			}
+			for _, user := range users {
+				if _, err = erasure.EraseInTx(ctx, tx, user, erasure.TriggerRetention, ""); err != nil {
+					return err
+				}
+			}
+			batch = len(users)
+			return nil
+		})
+		if err != nil {
+			return erased, err
+		}
+
+		erased += int64(batch)
+		if batch < expireBatchSize {
+			return erased, nil
+		}
+	}
+}
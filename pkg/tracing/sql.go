@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// executor records a span for every statement run with a context. The statements are recorded with their
+// placeholders, never with their arguments.
+type executor struct {
+	boil.ContextExecutor
+}
+
+// Executor wraps the executor of the queries, the sqlboiler models and hooks use the wrapped executor too
+func Executor(exec boil.ContextExecutor) boil.ContextExecutor {
+	if _, ok := exec.(executor); ok {
+		return exec
+	}
+	return executor{ContextExecutor: exec}
+}
+
+func (e executor) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startStatementSpan(ctx, query)
+	result, err := e.ContextExecutor.ExecContext(ctx, query, args...)
+	End(span, err)
+	return result, err
+}
+
+// QueryContext ends the span when the query returns, the iteration over the rows is not part of it
+func (e executor) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startStatementSpan(ctx, query)
+	rows, err := e.ContextExecutor.QueryContext(ctx, query, args...)
+	End(span, err)
+	return rows, err
+}
+
+func (e executor) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startStatementSpan(ctx, query)
+	row := e.ContextExecutor.QueryRowContext(ctx, query, args...)
+	End(span, row.Err())
+	return row
+}
+
+func startStatementSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := query
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemCockroachdb,
+		semconv.DBOperationKey.String(operation),
+		semconv.DBStatementKey.String(query),
+	))
+}
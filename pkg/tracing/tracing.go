@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"os"
+)
+
+const (
+	// instrumentationName names the tracer of the spans started by this service
+	instrumentationName = "user.app"
+
+	// DefaultServiceName is used when tracing.service_name is not configured
+	DefaultServiceName = "user.app"
+
+	// ExporterNone and the other exporters are the values of tracing.exporter
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterJaeger = "jaeger"
+)
+
+// Config is the [tracing] section of the configuration
+type Config struct {
+	// Exporter is none, stdout or jaeger. With none the trace context is still propagated but no span is recorded.
+	Exporter    string
+	ServiceName string `mapstructure:"service_name"`
+	// SampleRatio is the ratio of the root spans recorded, the sampling decision of the caller is always followed
+	SampleRatio float64 `mapstructure:"sample_ratio"`
+	// JaegerEndpoint is the HTTP endpoint of the Jaeger collector, like http://localhost:14268/api/traces
+	JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
+	// Writer receives the spans of the stdout exporter, os.Stdout when nil. It is not read from the configuration,
+	// the tests set it to collect the spans.
+	Writer io.Writer
+}
+
+// LoadConfig reads the [tracing] section of the configuration
+func LoadConfig() (Config, error) {
+	c := Config{
+		Exporter:    ExporterNone,
+		ServiceName: DefaultServiceName,
+		SampleRatio: 1,
+	}
+	if err := viper.UnmarshalKey("tracing", &c); err != nil {
+		return c, errors.Wrap(err, "cannot read tracing")
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return c, errors.Errorf("tracing.sample_ratio %v must be between 0 and 1", c.SampleRatio)
+	}
+	switch c.Exporter {
+	case ExporterNone, ExporterStdout:
+	case ExporterJaeger:
+		if len(c.JaegerEndpoint) == 0 {
+			return c, errors.New("tracing.jaeger_endpoint is required by the jaeger exporter")
+		}
+	default:
+		return c, errors.Errorf("unsupported tracing.exporter %q, it must be none, stdout or jaeger", c.Exporter)
+	}
+	return c, nil
+}
+
+// Init installs the global propagator, which reads and writes both the W3C trace context and the B3 headers, and the
+// tracer provider of the configured exporter. The returned function flushes the spans, it has to be called on exit.
+func Init(c Config) (func(ctx context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader|b3.B3SingleHeader)),
+	))
+
+	var processor sdktrace.SpanProcessor
+	switch c.Exporter {
+	case ExporterStdout:
+		writer := c.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create the stdout exporter")
+		}
+		// synchronous, the spans are written as soon as they end
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	case ExporterJaeger:
+		exporter, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(c.JaegerEndpoint)))
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create the jaeger exporter")
+		}
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(c.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span, child of the span of the context
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
+
+// End records the error on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
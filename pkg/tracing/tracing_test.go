@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"testing"
+)
+
+const (
+	callerTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	callerSpanID  = "00f067aa0ba902b7"
+)
+
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		TraceID string
+		SpanID  string
+	}
+}
+
+// fakeExecutor runs nothing, only the context executor methods are used by the tests
+type fakeExecutor struct{}
+
+func (fakeExecutor) Exec(string, ...interface{}) (sql.Result, error)                  { return nil, nil }
+func (fakeExecutor) Query(string, ...interface{}) (*sql.Rows, error)                  { return nil, nil }
+func (fakeExecutor) QueryRow(string, ...interface{}) *sql.Row                         { return nil }
+func (fakeExecutor) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+func (fakeExecutor) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (fakeExecutor) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+func initStdout(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	flush, err := Init(Config{Exporter: ExporterStdout, ServiceName: DefaultServiceName, SampleRatio: 1, Writer: &buf})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = flush(context.Background()) })
+	return &buf
+}
+
+func spans(t *testing.T, buf *bytes.Buffer) map[string]exportedSpan {
+	byName := make(map[string]exportedSpan)
+	decoder := json.NewDecoder(buf)
+	for {
+		var span exportedSpan
+		if err := decoder.Decode(&span); err == io.EOF {
+			return byName
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		byName[span.Name] = span
+	}
+}
+
+// call runs a unary call with the metadata through the interceptor, the handler runs a statement
+func call(t *testing.T, md metadata.MD) {
+	ctx := metadata.NewIncomingContext(context.Background(), md)
+	info := &grpc.UnaryServerInfo{FullMethod: "/message.UserApp/SignIn"}
+	_, err := UnaryServerInterceptor()(ctx, nil, info, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return Executor(fakeExecutor{}).ExecContext(ctx, "UPDATE users SET last_login = $1 WHERE id = $2", "now", "id")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkTrace(t *testing.T, buf *bytes.Buffer) {
+	byName := spans(t, buf)
+	server, ok := byName["message.UserApp/SignIn"]
+	if !ok {
+		t.Fatalf("no span for the call in %v", byName)
+	}
+	if server.SpanContext.TraceID != callerTraceID || server.Parent.SpanID != callerSpanID {
+		t.Errorf("the call does not continue the trace of the caller: %+v", server)
+	}
+	statement, ok := byName["UPDATE"]
+	if !ok {
+		t.Fatalf("no span for the statement in %v", byName)
+	}
+	if statement.SpanContext.TraceID != callerTraceID || statement.Parent.SpanID != server.SpanContext.SpanID {
+		t.Errorf("the statement is not a child of the call: %+v", statement)
+	}
+}
+
+func TestTraceContextIsPropagated(t *testing.T) {
+	buf := initStdout(t)
+	call(t, metadata.Pairs("traceparent", "00-"+callerTraceID+"-"+callerSpanID+"-01"))
+	checkTrace(t, buf)
+}
+
+func TestB3IsPropagated(t *testing.T) {
+	buf := initStdout(t)
+	call(t, metadata.Pairs("x-b3-traceid", callerTraceID, "x-b3-spanid", callerSpanID, "x-b3-sampled", "1"))
+	checkTrace(t, buf)
+}
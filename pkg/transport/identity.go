@@ -0,0 +1,48 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// PeerSANs returns the SANs of the verified client certificate of the gRPC peer, none when it did not present one
+func PeerSANs(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return subjectAltNames(info.State.VerifiedChains[0][0])
+}
+
+func subjectAltNames(cert *x509.Certificate) []string {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.URIs)+len(cert.EmailAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return append(sans, cert.EmailAddresses...)
+}
+
+// LoopbackCredentials are the credentials of the REST gateway dialing the server it runs in. The server certificate
+// is usually not issued for the loopback address, it is pinned instead: the peer has to present the certificate
+// currently served by the reloader.
+func LoopbackCredentials(r *Reloader) credentials.TransportCredentials {
+	return credentials.NewTLS(&tls.Config{
+		// the standard verification is replaced by the pinning below
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			if len(rawCerts) == 0 || string(rawCerts[0]) != string(r.Certificate()) {
+				return errors.New("the peer does not serve the certificate of this server")
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"net/http"
+	"strings"
+	"user.app/pkg/constants"
+)
+
+// gatewayToken marks the calls of the REST gateway of this process. It is drawn at startup and never leaves the
+// process, the other clients cannot forge it, even from the loopback.
+var gatewayToken = newGatewayToken()
+
+func newGatewayToken() string {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(token)
+}
+
+// gatewayCredentials adds the gateway token to the metadata of every call
+type gatewayCredentials struct{}
+
+func (gatewayCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{constants.MDKeyGateway: gatewayToken}, nil
+}
+
+func (gatewayCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// GatewayDialOption marks the calls of the REST gateway, ClientIP only trusts their forwarded addresses
+func GatewayDialOption() grpc.DialOption {
+	return grpc.WithPerRPCCredentials(gatewayCredentials{})
+}
+
+// ClientIP returns the IP of the gRPC peer, if any. The requests of the REST gateway come from the loopback, the
+// gateway appends the address of its own peer to the forwarded addresses and that one is returned instead. The
+// forwarded addresses of the other clients, including the loopback ones, are ignored.
+func ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() && fromGateway(ctx) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(constants.MDKeyForwardedFor); len(values) > 0 {
+			forwarded := strings.Split(values[len(values)-1], ",")
+			return strings.TrimSpace(forwarded[len(forwarded)-1])
+		}
+	}
+	return host
+}
+
+// HTTPClientIP returns the IP of the HTTP peer, the endpoints served over HTTP are not proxied by the gateway
+func HTTPClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// fromGateway returns whether the call carries the gateway token, the REST clients can add values of their own
+func fromGateway(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(constants.MDKeyGateway) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte(gatewayToken)) == 1 {
+			return true
+		}
+	}
+	return false
+}
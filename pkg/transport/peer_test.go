@@ -0,0 +1,68 @@
+package transport
+
+import (
+	"context"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"net"
+	"testing"
+	"user.app/pkg/constants"
+)
+
+func TestClientIP(t *testing.T) {
+	loopback := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 50000}
+	remote := &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 50000}
+
+	tests := []struct {
+		name string
+		addr net.Addr
+		md   metadata.MD
+		want string
+	}{
+		{"remote", remote, nil, "198.51.100.7"},
+		{"remote forwarding", remote, metadata.Pairs(constants.MDKeyForwardedFor, "192.0.2.1"), "198.51.100.7"},
+		{"loopback", loopback, nil, "127.0.0.1"},
+		{"loopback forwarding", loopback, metadata.Pairs(constants.MDKeyForwardedFor, "192.0.2.1"), "127.0.0.1"},
+		{
+			name: "loopback forwarding with a forged token",
+			addr: loopback,
+			md:   metadata.Pairs(constants.MDKeyForwardedFor, "192.0.2.1", constants.MDKeyGateway, "forged"),
+			want: "127.0.0.1",
+		},
+		{
+			name: "gateway",
+			addr: loopback,
+			md:   metadata.Pairs(constants.MDKeyForwardedFor, "192.0.2.1, 203.0.113.9", constants.MDKeyGateway, gatewayToken),
+			want: "203.0.113.9",
+		},
+		{
+			name: "gateway with a forged token",
+			addr: loopback,
+			md: metadata.Pairs(constants.MDKeyForwardedFor, "203.0.113.9",
+				constants.MDKeyGateway, "forged", constants.MDKeyGateway, gatewayToken),
+			want: "203.0.113.9",
+		},
+		{
+			name: "gateway token from a remote peer",
+			addr: remote,
+			md:   metadata.Pairs(constants.MDKeyForwardedFor, "192.0.2.1", constants.MDKeyGateway, gatewayToken),
+			want: "198.51.100.7",
+		},
+	}
+	for _, tt := range tests {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tt.addr})
+		if tt.md != nil {
+			ctx = metadata.NewIncomingContext(ctx, tt.md)
+		}
+		if got := ClientIP(ctx); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGatewayCredentials(t *testing.T) {
+	md, err := gatewayCredentials{}.GetRequestMetadata(context.Background())
+	if err != nil || md[constants.MDKeyGateway] != gatewayToken || len(gatewayToken) != 64 {
+		t.Fatalf("got %v, %v", md, err)
+	}
+}
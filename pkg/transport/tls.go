@@ -0,0 +1,211 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// nextProtos are negotiated with ALPN, gRPC requires HTTP/2 while the REST gateway and gRPC-Web also accept HTTP/1.1
+var nextProtos = []string{"h2", "http/1.1"}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Config is the [tls] section of the configuration
+type Config struct {
+	Enabled  bool
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+	// MinVersion is 1.2 or 1.3, it defaults to 1.2
+	MinVersion string `mapstructure:"min_version"`
+	// CipherSuites are the names of the TLS 1.2 cipher suites, the secure suites of crypto/tls are used when empty.
+	// The TLS 1.3 suites cannot be configured.
+	CipherSuites []string `mapstructure:"cipher_suites"`
+	// ClientCAFile enables mutual TLS, the client certificates it signs are verified when the clients present one
+	ClientCAFile string `mapstructure:"client_ca_file"`
+}
+
+// LoadConfig reads and validates the [tls] section of the configuration
+func LoadConfig() (Config, error) {
+	var c Config
+	if err := viper.UnmarshalKey("tls", &c); err != nil {
+		return c, errors.Wrap(err, "cannot read tls")
+	}
+	// the service accounts used to be configured here, they are now users having a certificate_san
+	if viper.IsSet("tls.service_accounts") {
+		return c, errors.New("tls.service_accounts is no longer supported, create the service accounts with " +
+			"CreateServiceAccount and their certificate_san instead")
+	}
+	if !c.Enabled {
+		return c, nil
+	}
+	if len(c.CertFile) == 0 || len(c.KeyFile) == 0 {
+		return c, errors.New("tls.cert_file and tls.key_file are required when tls is enabled")
+	}
+	if _, err := c.minVersion(); err != nil {
+		return c, err
+	}
+	if _, err := c.cipherSuites(); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+func (c Config) minVersion() (uint16, error) {
+	if len(c.MinVersion) == 0 {
+		return tls.VersionTLS12, nil
+	}
+	version, ok := tlsVersions[c.MinVersion]
+	if !ok {
+		return 0, errors.Errorf("unsupported tls.min_version %q, it must be 1.2 or 1.3", c.MinVersion)
+	}
+	return version, nil
+}
+
+func (c Config) cipherSuites() ([]uint16, error) {
+	if len(c.CipherSuites) == 0 {
+		return nil, nil
+	}
+	secure := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		secure[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(c.CipherSuites))
+	for _, name := range c.CipherSuites {
+		id, ok := secure[name]
+		if !ok {
+			return nil, errors.Errorf("unsupported or insecure cipher suite %q", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Reloader serves the TLS configuration built from the files of the Config, it is rebuilt whenever one of them
+// changes. A failed reload is logged and the previous configuration is kept.
+type Reloader struct {
+	config Config
+
+	mu      sync.RWMutex
+	current *tls.Config
+}
+
+// NewReloader loads the certificates, it fails when they cannot be loaded
+func NewReloader(config Config) (*Reloader, error) {
+	r := &Reloader{config: config}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the files again
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return errors.Wrap(err, "cannot load the certificate")
+	}
+	minVersion, err := r.config.minVersion()
+	if err != nil {
+		return err
+	}
+	cipherSuites, err := r.config.cipherSuites()
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+		CipherSuites: cipherSuites,
+		NextProtos:   nextProtos,
+	}
+	if len(r.config.ClientCAFile) > 0 {
+		pem, err := ioutil.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return errors.Wrap(err, "cannot read the client CA")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.Errorf("no certificate found in %s", r.config.ClientCAFile)
+		}
+		config.ClientCAs = pool
+		// the clients without a certificate are authenticated with their bearer token
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	r.mu.Lock()
+	r.current = config
+	r.mu.Unlock()
+	return nil
+}
+
+// TLSConfig returns the configuration of the listener, every handshake uses the latest reloaded configuration
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		NextProtos: nextProtos,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			return r.current, nil
+		},
+	}
+}
+
+// Certificate returns the leaf of the served certificate
+func (r *Reloader) Certificate() []byte {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current.Certificates[0].Certificate[0]
+}
+
+// Watch reloads the configuration when the files change, until done is closed. The directories are watched rather
+// than the files since the files are usually replaced, like the symbolic links of a mounted Kubernetes secret.
+func (r *Reloader) Watch(done <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "cannot watch the certificates")
+	}
+	defer watcher.Close()
+
+	files := make(map[string]bool)
+	for _, file := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if len(file) == 0 {
+			continue
+		}
+		file = filepath.Clean(file)
+		files[file] = true
+		if err = watcher.Add(filepath.Dir(file)); err != nil {
+			return errors.Wrapf(err, "cannot watch %s", file)
+		}
+	}
+
+	for {
+		select {
+		case <-done:
+			return nil
+		case event := <-watcher.Events:
+			if !files[filepath.Clean(event.Name)] && !strings.HasPrefix(filepath.Base(event.Name), "..") {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				log.Error().Err(err).Msg("certificate reload failed, the previous certificate is still served")
+				continue
+			}
+			log.Info().Msg("certificates reloaded")
+		case err := <-watcher.Errors:
+			log.Error().Err(err).Msg("certificate watcher error")
+		}
+	}
+}
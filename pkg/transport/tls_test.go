@@ -0,0 +1,22 @@
+package transport
+
+import (
+	"github.com/spf13/viper"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("tls", map[string]interface{}{"enabled": false})
+	if _, err := LoadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	viper.Set("tls", map[string]interface{}{
+		"enabled":          false,
+		"service_accounts": []interface{}{map[string]interface{}{"name": "billing", "san": "billing.example.com"}},
+	})
+	if _, err := LoadConfig(); err == nil {
+		t.Fatal("tls.service_accounts is accepted, the service accounts it configures would stop being authenticated")
+	}
+}
@@ -0,0 +1,299 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"user.app/message"
+	"user.app/pkg/auth"
+	"user.app/pkg/normalize"
+)
+
+const (
+	// MaxUsernameLength is the length of users.username
+	MaxUsernameLength = 50
+	// MaxEmailLength is the length of users.email
+	MaxEmailLength = 254
+	// MinPasswordLength is the shortest password accepted on sign up
+	MinPasswordLength = 8
+	// MaxPasswordLength caps the input given to the password hasher
+	MaxPasswordLength = 128
+	// MaxAPIKeyNameLength is the length of api_keys.name
+	MaxAPIKeyNameLength = 100
+	// MaxCertificateSANLength is the length of users.certificate_san
+	MaxCertificateSANLength = 255
+)
+
+var usernamePattern = regexp.MustCompile(`^[\p{L}\p{N}._-]+$`)
+
+type (
+	// Check validates a single field value, it returns the description of the violation or an empty string
+	Check func(value string) string
+
+	// Field binds a request field to the checks it has to pass
+	Field struct {
+		Name   string
+		Value  string
+		Checks []Check
+	}
+)
+
+// Required rejects empty values
+func Required(value string) string {
+	if len(value) == 0 {
+		return "must not be empty"
+	}
+	return ""
+}
+
+// MinLength rejects values having less than n characters
+func MinLength(n int) Check {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) < n {
+			return fmt.Sprintf("must be at least %d characters long", n)
+		}
+		return ""
+	}
+}
+
+// MaxLength rejects values having more than n characters
+func MaxLength(n int) Check {
+	return func(value string) string {
+		if utf8.RuneCountInString(value) > n {
+			return fmt.Sprintf("must be at most %d characters long", n)
+		}
+		return ""
+	}
+}
+
+// Username allows letters, digits, dots, underscores and hyphens
+func Username(value string) string {
+	if !usernamePattern.MatchString(value) {
+		return "may only contain letters, digits, '.', '_' and '-'"
+	}
+	return ""
+}
+
+// Email accepts a bare address, like alice@example.com, without a display name
+func Email(value string) string {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		return "must be a valid email address"
+	}
+	return ""
+}
+
+// CanonicalUsername rejects usernames that cannot be normalized, see normalize.Username
+func CanonicalUsername(value string) string {
+	if _, err := normalize.Username(value); err != nil {
+		if err == normalize.ErrMixedScript {
+			return "must not mix characters from different scripts"
+		}
+		return "must not contain disallowed characters"
+	}
+	return ""
+}
+
+// CanonicalEmail rejects email addresses that cannot be normalized, see normalize.Email
+func CanonicalEmail(value string) string {
+	if _, err := normalize.Email(value); err != nil {
+		return "must have a valid domain"
+	}
+	return ""
+}
+
+// Optional applies the checks only when the value is not empty
+func Optional(checks ...Check) Check {
+	return func(value string) string {
+		if len(value) == 0 {
+			return ""
+		}
+		for _, check := range checks {
+			if description := check(value); len(description) > 0 {
+				return description
+			}
+		}
+		return ""
+	}
+}
+
+// Cursor accepts the non-negative integers, like the cursors of the outbox events
+func Cursor(value string) string {
+	if cursor, err := strconv.ParseInt(value, 10, 64); err != nil || cursor < 0 {
+		return "must be a cursor returned by the server"
+	}
+	return ""
+}
+
+// OneOf accepts the listed values only
+func OneOf(values ...string) Check {
+	return func(value string) string {
+		for _, v := range values {
+			if value == v {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s", strings.Join(values, ", "))
+	}
+}
+
+// Fields returns the validation rules for a request message, nil if the message has none
+func Fields(req interface{}) []Field {
+	switch r := req.(type) {
+	case *message.AuthRequest:
+		return []Field{
+			{Name: "username", Value: r.Username, Checks: []Check{Required, MaxLength(MaxUsernameLength)}},
+			{Name: "password", Value: r.Password, Checks: []Check{Required, MaxLength(MaxPasswordLength)}},
+		}
+	case *message.CreateUserRequest:
+		return []Field{
+			{Name: "username", Value: r.Username, Checks: []Check{Required, MaxLength(MaxUsernameLength), Username, CanonicalUsername}},
+			{Name: "email", Value: r.Email, Checks: []Check{Required, MaxLength(MaxEmailLength), Email, CanonicalEmail}},
+			{Name: "password", Value: r.Password, Checks: []Check{MinLength(MinPasswordLength), MaxLength(MaxPasswordLength)}},
+		}
+	case *message.UpdateUserRequest:
+		atLeastOne := func(string) string {
+			if len(r.Username) == 0 && len(r.Email) == 0 {
+				return "either username or email must be set"
+			}
+			return ""
+		}
+		return []Field{
+			{Name: "username", Value: r.Username, Checks: []Check{atLeastOne, Optional(MaxLength(MaxUsernameLength), Username, CanonicalUsername)}},
+			{Name: "email", Value: r.Email, Checks: []Check{Optional(MaxLength(MaxEmailLength), Email, CanonicalEmail)}},
+		}
+	case *message.ExportRequest:
+		return []Field{
+			{Name: "format", Value: r.Format.String(), Checks: []Check{exportFormat}},
+		}
+	case *message.ExportUserDataRequest:
+		return []Field{
+			{Name: "user_id", Value: r.UserId, Checks: []Check{Required}},
+			{Name: "format", Value: r.Format.String(), Checks: []Check{exportFormat}},
+		}
+	case *message.WatchUserEventsRequest:
+		return []Field{
+			{Name: "cursor", Value: r.Cursor, Checks: []Check{Optional(Cursor)}},
+		}
+	case *message.CreateAPIKeyRequest:
+		return apiKeyFields(r.Name, r.Scopes)
+	case *message.RevokeAPIKeyRequest:
+		return []Field{
+			{Name: "key_id", Value: r.KeyId, Checks: []Check{Required}},
+		}
+	case *message.CreateServiceAccountRequest:
+		return []Field{
+			{Name: "name", Value: r.Name, Checks: []Check{Required, MaxLength(MaxUsernameLength), Username, CanonicalUsername}},
+			{Name: "certificate_san", Value: r.CertificateSan, Checks: []Check{MaxLength(MaxCertificateSANLength)}},
+		}
+	case *message.DeleteServiceAccountRequest:
+		return []Field{
+			{Name: "service_account_id", Value: r.ServiceAccountId, Checks: []Check{Required}},
+		}
+	case *message.CreateServiceAccountAPIKeyRequest:
+		return append([]Field{
+			{Name: "service_account_id", Value: r.ServiceAccountId, Checks: []Check{Required}},
+		}, apiKeyFields(r.Name, r.Scopes)...)
+	case *message.ListServiceAccountAPIKeysRequest:
+		return []Field{
+			{Name: "service_account_id", Value: r.ServiceAccountId, Checks: []Check{Required}},
+		}
+	case *message.RevokeServiceAccountAPIKeyRequest:
+		return []Field{
+			{Name: "service_account_id", Value: r.ServiceAccountId, Checks: []Check{Required}},
+			{Name: "key_id", Value: r.KeyId, Checks: []Check{Required}},
+		}
+	}
+	return nil
+}
+
+// exportFormat accepts the formats of the export document
+var exportFormat = OneOf(message.ExportFormat_EXPORT_FORMAT_JSON.String(), message.ExportFormat_EXPORT_FORMAT_ZIP.String())
+
+// apiKeyFields are the rules of the name and the scopes of a new API key
+func apiKeyFields(name string, scopes []string) []Field {
+	atLeastOne := func(string) string {
+		if len(scopes) == 0 {
+			return "at least one scope must be granted"
+		}
+		return ""
+	}
+	fields := []Field{
+		{Name: "name", Value: name, Checks: []Check{Required, MaxLength(MaxAPIKeyNameLength)}},
+		{Name: "scopes", Checks: []Check{atLeastOne}},
+	}
+	for i, scope := range scopes {
+		fields = append(fields, Field{Name: fmt.Sprintf("scopes[%d]", i), Value: scope, Checks: []Check{OneOf(auth.Scopes...)}})
+	}
+	return fields
+}
+
+// Validate runs the rules of the request and returns one violation per invalid field
+func Validate(req interface{}) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, field := range Fields(req) {
+		for _, check := range field.Checks {
+			if description := check(field.Value); len(description) > 0 {
+				violations = append(violations, &errdetails.BadRequest_FieldViolation{
+					Field:       field.Name,
+					Description: description,
+				})
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// Error converts the violations to an InvalidArgument status carrying the BadRequest details
+func Error(violations []*errdetails.BadRequest_FieldViolation) error {
+	st := status.New(codes.InvalidArgument, "invalid request sent")
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		log.Error().Err(err).Msg("cannot attach the bad request details")
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// UnaryServerInterceptor rejects invalid requests before they reach the handlers
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if violations := Validate(req); len(violations) > 0 {
+			return nil, Error(violations)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects the invalid messages received on the streams, like the requests of the server
+// streaming RPCs, before the handlers get them
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatedStream{ServerStream: ss})
+	}
+}
+
+// validatedStream validates every message it receives
+type validatedStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if violations := Validate(m); len(violations) > 0 {
+		return Error(violations)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"context"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"strings"
+	"testing"
+	"user.app/message"
+)
+
+func TestChecks(t *testing.T) {
+	tests := []struct {
+		name  string
+		check Check
+		value string
+		valid bool
+	}{
+		{"required", Required, "a", true},
+		{"required empty", Required, "", false},
+		{"max length", MaxLength(3), "abc", true},
+		{"max length exceeded", MaxLength(3), "abcd", false},
+		{"max length counts characters", MaxLength(3), "äöü", true},
+		{"min length", MinLength(3), "ab", false},
+		{"one of", OneOf("read", "write"), "write", true},
+		{"one of other", OneOf("read", "write"), "admin", false},
+		{"one of is case sensitive", OneOf("read", "write"), "READ", false},
+		{"optional empty", Optional(MinLength(3)), "", true},
+		{"optional set", Optional(MinLength(3)), "ab", false},
+		{"username", Username, "alice.b_c-1", true},
+		{"username space", Username, "alice b", false},
+		{"email", Email, "alice@example.com", true},
+		{"email display name", Email, "Alice <alice@example.com>", false},
+		{"cursor", Cursor, "42", true},
+		{"cursor negative", Cursor, "-1", false},
+		{"cursor not a number", Cursor, "abc", false},
+	}
+	for _, tt := range tests {
+		if description := tt.check(tt.value); (len(description) == 0) != tt.valid {
+			t.Errorf("%s: %q got %q, want valid %v", tt.name, tt.value, description, tt.valid)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  interface{}
+		want map[string]string
+	}{
+		{
+			name: "valid user",
+			req:  &message.CreateUserRequest{Username: "alice", Email: "alice@example.com", Password: "correct horse"},
+		},
+		{
+			name: "one violation per field",
+			req:  &message.CreateUserRequest{Email: strings.Repeat("a", 250) + "@example.com", Password: "short"},
+			want: map[string]string{
+				"username": "must not be empty",
+				"email":    "must be at most 254 characters long",
+				"password": "must be at least 8 characters long",
+			},
+		},
+		{
+			name: "indexed scopes",
+			req:  &message.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"read", "root"}},
+			want: map[string]string{"scopes[1]": "must be one of read, write, admin"},
+		},
+		{
+			name: "stream request",
+			req:  &message.ExportUserDataRequest{Format: message.ExportFormat(7)},
+			want: map[string]string{
+				"user_id": "must not be empty",
+				"format":  "must be one of EXPORT_FORMAT_JSON, EXPORT_FORMAT_ZIP",
+			},
+		},
+		{name: "without rules", req: &message.Empty{}},
+	}
+	for _, tt := range tests {
+		got := map[string]string{}
+		for _, violation := range Validate(tt.req) {
+			got[violation.Field] = violation.Description
+		}
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	violations := Validate(&message.AuthRequest{Username: "alice"})
+	st := status.Convert(Error(violations))
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %s, want InvalidArgument", st.Code())
+	}
+	if len(st.Details()) != 1 {
+		t.Fatalf("got %d details, want the BadRequest", len(st.Details()))
+	}
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	if !ok {
+		t.Fatalf("got %T, want the BadRequest", st.Details()[0])
+	}
+	if len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "password" ||
+		badRequest.FieldViolations[0].Description != "must not be empty" {
+		t.Fatalf("got the violations %v", badRequest.FieldViolations)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	called := false
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		called = true
+		return req, nil
+	}
+
+	_, err := interceptor(context.Background(), &message.AuthRequest{}, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.InvalidArgument || called {
+		t.Fatalf("got %v, the handler was called: %v", err, called)
+	}
+	if _, err = interceptor(context.Background(), &message.AuthRequest{Username: "alice", Password: "secret"},
+		&grpc.UnaryServerInfo{}, handler); err != nil || !called {
+		t.Fatalf("got %v, the handler was called: %v", err, called)
+	}
+}
+
+// stream receives the request of a server streaming RPC
+type stream struct {
+	req *message.WatchUserEventsRequest
+}
+
+func (s *stream) SetHeader(metadata.MD) error  { return nil }
+func (s *stream) SendHeader(metadata.MD) error { return nil }
+func (s *stream) SetTrailer(metadata.MD)       {}
+func (s *stream) Context() context.Context     { return context.Background() }
+func (s *stream) SendMsg(interface{}) error    { return nil }
+func (s *stream) RecvMsg(m interface{}) error {
+	*m.(*message.WatchUserEventsRequest) = *s.req
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := StreamServerInterceptor()
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.RecvMsg(&message.WatchUserEventsRequest{})
+	}
+
+	tests := []struct {
+		cursor string
+		code   codes.Code
+	}{
+		{"", codes.OK},
+		{"0", codes.OK},
+		{"12", codes.OK},
+		{"-3", codes.InvalidArgument},
+		{"next", codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		ss := &stream{req: &message.WatchUserEventsRequest{Cursor: tt.cursor}}
+		if err := interceptor(nil, ss, &grpc.StreamServerInfo{}, handler); status.Code(err) != tt.code {
+			t.Errorf("cursor %q: got %v, want %s", tt.cursor, err, tt.code)
+		}
+	}
+}
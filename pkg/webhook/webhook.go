@@ -0,0 +1,342 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"github.com/volatiletech/null/v8"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+	"user.app/models"
+	"user.app/pkg/conn"
+	"user.app/pkg/outbox"
+	"user.app/pkg/query"
+)
+
+const (
+	// DefaultDispatchIntervalInSeconds is used when webhooks.dispatch_interval is not configured
+	DefaultDispatchIntervalInSeconds = 5
+	// DefaultMaxAttempts is used when webhooks.max_attempts is not configured
+	DefaultMaxAttempts = 10
+	// DefaultBackoffInSeconds is used when webhooks.backoff is not configured
+	DefaultBackoffInSeconds = 30
+	// DefaultMaxBackoffInSeconds is used when webhooks.max_backoff is not configured
+	DefaultMaxBackoffInSeconds = 60 * 60
+	// DefaultTimeoutInSeconds is used when webhooks.timeout is not configured
+	DefaultTimeoutInSeconds = 10
+	// DefaultOutboxRetentionInHours is used when webhooks.outbox_retention is not configured
+	DefaultOutboxRetentionInHours = 7 * 24
+
+	// SignatureHeader carries "sha256=" followed by the hex encoded HMAC-SHA256 of "<timestamp>.<body>"
+	SignatureHeader = "X-UserApp-Signature"
+	// TimestampHeader carries the unix time of the attempt, receivers should reject old timestamps to prevent replays
+	TimestampHeader = "X-UserApp-Timestamp"
+	// EventHeader carries the event type
+	EventHeader = "X-UserApp-Event"
+	// DeliveryHeader carries the delivery id, it is the same for every attempt so receivers can deduplicate
+	DeliveryHeader = "X-UserApp-Delivery"
+
+	// claimBatchSize is the number of deliveries claimed at once
+	claimBatchSize = 50
+	// pruneInterval is how often the delivered outbox events are pruned
+	pruneInterval = time.Hour
+	// maxErrorLength caps the error stored on a failed delivery
+	maxErrorLength = 1000
+)
+
+var (
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrDeliveryPending  = errors.New("webhook delivery is still pending")
+)
+
+// Endpoint is a webhook receiver configured in [[webhooks.endpoints]]
+type Endpoint struct {
+	Name   string `mapstructure:"name"`
+	URL    string `mapstructure:"url"`
+	Secret string `mapstructure:"secret"`
+}
+
+// Endpoints returns the configured webhook endpoints
+func Endpoints() ([]Endpoint, error) {
+	var endpoints []Endpoint
+	if err := viper.UnmarshalKey("webhooks.endpoints", &endpoints); err != nil {
+		return nil, errors.Wrap(err, "cannot read webhooks.endpoints")
+	}
+
+	names := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		if len(endpoint.Name) == 0 || len(endpoint.URL) == 0 || len(endpoint.Secret) == 0 {
+			return nil, errors.Errorf("webhook endpoint %q must have a name, an url and a secret", endpoint.Name)
+		}
+		if names[endpoint.Name] {
+			return nil, errors.Errorf("webhook endpoint %q is configured twice", endpoint.Name)
+		}
+		names[endpoint.Name] = true
+	}
+	return endpoints, nil
+}
+
+// Names returns the names of the endpoints, the outbox schedules the deliveries by name
+func Names(endpoints []Endpoint) []string {
+	names := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		names = append(names, endpoint.Name)
+	}
+	return names
+}
+
+// Sign returns the value of the SignatureHeader for the body sent at the timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the SignatureHeader received with the body, it is meant for the receivers written in Go
+func Verify(secret, signature string, timestamp int64, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// Backoff is the wait after the given number of failed attempts, base doubled after every attempt up to max
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		return max
+	}
+	return backoff
+}
+
+// Dispatcher delivers the outbox events to the webhook endpoints. A failed delivery is retried with an exponential
+// backoff and dead-lettered once it has used all its attempts.
+type Dispatcher struct {
+	db          *sql.DB
+	client      *http.Client
+	endpoints   map[string]Endpoint
+	interval    time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	retention   time.Duration
+}
+
+// NewDispatcher is the constructor for the Dispatcher
+func NewDispatcher(db *sql.DB, endpoints []Endpoint) *Dispatcher {
+	d := &Dispatcher{
+		db:          db,
+		endpoints:   make(map[string]Endpoint, len(endpoints)),
+		interval:    time.Duration(configured("webhooks.dispatch_interval", DefaultDispatchIntervalInSeconds)) * time.Second,
+		maxAttempts: configured("webhooks.max_attempts", DefaultMaxAttempts),
+		backoff:     time.Duration(configured("webhooks.backoff", DefaultBackoffInSeconds)) * time.Second,
+		maxBackoff:  time.Duration(configured("webhooks.max_backoff", DefaultMaxBackoffInSeconds)) * time.Second,
+		retention:   time.Duration(configured("webhooks.outbox_retention", DefaultOutboxRetentionInHours)) * time.Hour,
+		client: &http.Client{
+			Timeout: time.Duration(configured("webhooks.timeout", DefaultTimeoutInSeconds)) * time.Second,
+		},
+	}
+	for _, endpoint := range endpoints {
+		d.endpoints[endpoint.Name] = endpoint
+	}
+	return d
+}
+
+// configured returns the positive integer configured for the key or the default
+func configured(key string, defaultValue int) int {
+	if value := viper.GetInt(key); value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// Run dispatches on every interval until the context is done. Failed dispatches are logged and retried on the next
+// tick. The deliveries in progress are not canceled with the context, a shutdown waits for them to be recorded.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		pass := context.Background()
+		if _, err := d.DispatchOnce(pass); err != nil {
+			log.Error().Err(err).Msg("cannot dispatch the webhooks")
+		}
+		if time.Since(lastPrune) >= pruneInterval {
+			if _, err := d.PruneOnce(pass); err != nil {
+				log.Error().Err(err).Msg("cannot prune the outbox")
+			}
+			lastPrune = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce attempts every due delivery and returns how many were delivered
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	var delivered int
+	for {
+		var deliveries models.WebhookDeliverySlice
+		err := conn.ExecuteTx(ctx, d.db, func(tx *sql.Tx) error {
+			now := time.Now()
+			var err error
+			// the claim outlives the attempts of the whole batch, they are recorded before anyone else can claim them
+			claimedUntil := now.Add(claimBatchSize * d.client.Timeout)
+			deliveries, err = query.ClaimDueDeliveries(ctx, tx, outbox.StatusPending, now, claimedUntil, claimBatchSize)
+			return err
+		})
+		if err != nil {
+			return delivered, errors.WithMessage(err, "cannot claim the due deliveries")
+		}
+
+		for _, delivery := range deliveries {
+			sendErr := d.attempt(ctx, delivery)
+			if err = d.record(ctx, delivery, sendErr); err != nil {
+				return delivered, err
+			}
+			if sendErr == nil {
+				delivered++
+			}
+		}
+
+		if len(deliveries) < claimBatchSize {
+			return delivered, nil
+		}
+	}
+}
+
+// PruneOnce deletes the outbox events older than the retention whose deliveries have all been delivered
+func (d *Dispatcher) PruneOnce(ctx context.Context) (int64, error) {
+	var pruned int64
+	err := conn.ExecuteTx(ctx, d.db, func(tx *sql.Tx) error {
+		var err error
+		pruned, err = query.PruneOutbox(ctx, tx, time.Now().Add(-d.retention), outbox.StatusDelivered)
+		return err
+	})
+	return pruned, err
+}
+
+// attempt sends the delivery to its endpoint, a delivery to an endpoint that has been removed from the config fails
+func (d *Dispatcher) attempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	endpoint, ok := d.endpoints[delivery.Endpoint]
+	if !ok {
+		return errors.Errorf("endpoint %q is not configured", delivery.Endpoint)
+	}
+	if delivery.R == nil || delivery.R.Outbox == nil {
+		return errors.New("the outbox event is not loaded")
+	}
+	return d.Send(ctx, endpoint, delivery.ID, delivery.R.Outbox)
+}
+
+// Send posts the outbox event to the endpoint, any response outside of 2xx is a failure
+func (d *Dispatcher) Send(ctx context.Context, endpoint Endpoint, deliveryID string, event *models.Outbox) error {
+	body := []byte(event.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create the request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.EventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(endpoint.Secret, timestamp, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot post the webhook")
+	}
+	defer res.Body.Close()
+	// drained so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("the endpoint answered %s", res.Status)
+	}
+	return nil
+}
+
+// record stores the outcome of an attempt, the delivery is dead-lettered once it has used all its attempts
+func (d *Dispatcher) record(ctx context.Context, delivery *models.WebhookDelivery, sendErr error) error {
+	now := time.Now()
+	delivery.Attempts++
+	if sendErr == nil {
+		delivery.Status = outbox.StatusDelivered
+		delivery.DeliveredAt = null.TimeFrom(now)
+		delivery.LastError = ""
+	} else {
+		delivery.LastError = sendErr.Error()
+		if len(delivery.LastError) > maxErrorLength {
+			delivery.LastError = delivery.LastError[:maxErrorLength]
+		}
+		delivery.NextAttemptAt = now.Add(Backoff(delivery.Attempts, d.backoff, d.maxBackoff))
+		if delivery.Attempts >= d.maxAttempts {
+			delivery.Status = outbox.StatusDead
+		}
+		log.Warn().Err(sendErr).Str("delivery_id", delivery.ID).Str("endpoint", delivery.Endpoint).
+			Int("attempts", delivery.Attempts).Str("status", delivery.Status).Msg("webhook delivery failed")
+	}
+
+	err := conn.ExecuteTx(ctx, d.db, func(tx *sql.Tx) error {
+		return query.UpdateWebhookDelivery(ctx, tx, delivery, []string{
+			models.WebhookDeliveryColumns.Status,
+			models.WebhookDeliveryColumns.Attempts,
+			models.WebhookDeliveryColumns.LastError,
+			models.WebhookDeliveryColumns.NextAttemptAt,
+			models.WebhookDeliveryColumns.DeliveredAt,
+		})
+	})
+	return errors.WithMessage(err, "cannot record the delivery attempt")
+}
+
+// Replay schedules a delivered or dead delivery again, with a fresh set of attempts
+func Replay(ctx context.Context, db *sql.DB, deliveryID string) (*models.WebhookDelivery, error) {
+	var delivery *models.WebhookDelivery
+	err := conn.ExecuteTx(ctx, db, func(tx *sql.Tx) error {
+		var err error
+		delivery, err = query.FindWebhookDelivery(ctx, tx, deliveryID)
+		if err != nil {
+			if err == query.ErrNoRowsFound {
+				return ErrDeliveryNotFound
+			}
+			return err
+		}
+		if delivery.Status == outbox.StatusPending {
+			return ErrDeliveryPending
+		}
+
+		delivery.Status = outbox.StatusPending
+		delivery.Attempts = 0
+		delivery.LastError = ""
+		delivery.NextAttemptAt = time.Now()
+		return query.UpdateWebhookDelivery(ctx, tx, delivery, []string{
+			models.WebhookDeliveryColumns.Status,
+			models.WebhookDeliveryColumns.Attempts,
+			models.WebhookDeliveryColumns.LastError,
+			models.WebhookDeliveryColumns.NextAttemptAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+	"user.app/models"
+)
+
+func testEvent() *models.Outbox {
+	return &models.Outbox{
+		ID:        "5b0fa37e-5d43-4ad5-a0e5-6b1d4a2d4a0c",
+		EventType: "user.created",
+		UserID:    "0c4d7f2e-8a5b-4f0e-9f3a-2a1b3c4d5e6f",
+		Payload:   []byte(`{"id":"5b0fa37e-5d43-4ad5-a0e5-6b1d4a2d4a0c","type":"user.created"}`),
+	}
+}
+
+func TestSendSignsTheEvent(t *testing.T) {
+	endpoint := Endpoint{Name: "receiver", Secret: "s3cr3t"}
+	event := testEvent()
+
+	received := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("cannot read the body: %v", err)
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil {
+			t.Errorf("invalid timestamp header: %v", err)
+		}
+		if !Verify(endpoint.Secret, r.Header.Get(SignatureHeader), timestamp, body) {
+			t.Errorf("the signature %q does not match the body", r.Header.Get(SignatureHeader))
+		}
+		if string(body) != string(event.Payload) {
+			t.Errorf("got body %s, want %s", body, event.Payload)
+		}
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	endpoint.URL = server.URL
+
+	d := NewDispatcher(nil, []Endpoint{endpoint})
+	if err := d.Send(context.Background(), endpoint, "delivery-1", event); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	r := <-received
+	if got := r.Header.Get(EventHeader); got != event.EventType {
+		t.Errorf("got event header %q, want %q", got, event.EventType)
+	}
+	if got := r.Header.Get(DeliveryHeader); got != "delivery-1" {
+		t.Errorf("got delivery header %q, want %q", got, "delivery-1")
+	}
+	if got := r.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("got content type %q, want application/json", got)
+	}
+}
+
+func TestSendFailsOnErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	endpoint := Endpoint{Name: "receiver", URL: server.URL, Secret: "s3cr3t"}
+	d := NewDispatcher(nil, []Endpoint{endpoint})
+	if err := d.Send(context.Background(), endpoint, "delivery-1", testEvent()); err == nil {
+		t.Fatal("Send succeeded on a 503 response")
+	}
+}
+
+func TestAttemptFailsForUnknownEndpoint(t *testing.T) {
+	d := NewDispatcher(nil, nil)
+	delivery := &models.WebhookDelivery{ID: "delivery-1", Endpoint: "removed"}
+	if err := d.attempt(context.Background(), delivery); err == nil {
+		t.Fatal("attempt succeeded for an endpoint that is not configured")
+	}
+}
+
+func TestVerifyRejectsTamperedBody(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+	signature := Sign("s3cr3t", 1599000000, body)
+
+	if !Verify("s3cr3t", signature, 1599000000, body) {
+		t.Fatal("the signature of the body is rejected")
+	}
+	if Verify("s3cr3t", signature, 1599000000, []byte(`{"type":"user.deleted"}`)) {
+		t.Error("the signature of another body is accepted")
+	}
+	if Verify("s3cr3t", signature, 1599000001, body) {
+		t.Error("the signature of another timestamp is accepted")
+	}
+	if Verify("other", signature, 1599000000, body) {
+		t.Error("the signature of another secret is accepted")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base, max := 30*time.Second, 5*time.Minute
+	for attempts, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		4:  4 * time.Minute,
+		5:  5 * time.Minute,
+		50: 5 * time.Minute,
+	} {
+		if got := Backoff(attempts, base, max); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}